@@ -0,0 +1,329 @@
+package addresses
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+// Address is a postal address as Pingen uses it for recipients and senders.
+// Country is an ISO 3166-1 alpha-2 code. Region is the state, province or territory,
+// which addresses in the US, Canada and Australia require.
+type Address struct {
+	Name    string `json:"name"`
+	Street  string `json:"street"`
+	Number  string `json:"number"`
+	Zip     string `json:"zip"`
+	City    string `json:"city"`
+	Region  string `json:"region,omitempty"`
+	Country string `json:"country"`
+}
+
+type layout int
+
+const (
+	// Street then number, zip before city: most of continental Europe.
+	layoutStreetFirst layout = iota
+	// Number then street, zip after city: English-speaking countries.
+	layoutNumberFirst
+	// Like layoutNumberFirst, with the postcode on a line of its own.
+	layoutPostcodeLine
+	// Like layoutNumberFirst, with the region between city and zip: "CITY ST ZIP".
+	layoutRegion
+)
+
+var layouts = map[string]layout{
+	"US": layoutRegion,
+	"CA": layoutRegion,
+	"AU": layoutRegion,
+	"NZ": layoutNumberFirst,
+	"IE": layoutNumberFirst,
+	"GB": layoutPostcodeLine,
+}
+
+var zipPatterns = map[string]*regexp.Regexp{
+	"CH": regexp.MustCompile(`^\d{4}$`),
+	"LI": regexp.MustCompile(`^\d{4}$`),
+	"AT": regexp.MustCompile(`^\d{4}$`),
+	"BE": regexp.MustCompile(`^\d{4}$`),
+	"DK": regexp.MustCompile(`^\d{4}$`),
+	"DE": regexp.MustCompile(`^\d{5}$`),
+	"FR": regexp.MustCompile(`^\d{5}$`),
+	"IT": regexp.MustCompile(`^\d{5}$`),
+	"ES": regexp.MustCompile(`^\d{5}$`),
+	"FI": regexp.MustCompile(`^\d{5}$`),
+	"PL": regexp.MustCompile(`^\d{2}-\d{3}$`),
+	"PT": regexp.MustCompile(`^\d{4}-\d{3}$`),
+	"NL": regexp.MustCompile(`^\d{4} ?[A-Z]{2}$`),
+	"SE": regexp.MustCompile(`^\d{3} ?\d{2}$`),
+	"US": regexp.MustCompile(`^\d{5}(-\d{4})?$`),
+	"CA": regexp.MustCompile(`^[A-Z]\d[A-Z] ?\d[A-Z]\d$`),
+	"AU": regexp.MustCompile(`^\d{4}$`),
+	"GB": regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? ?\d[A-Z]{2}$`),
+}
+
+var countryNames = map[string]string{
+	"AT": "AUSTRIA",
+	"AU": "AUSTRALIA",
+	"BE": "BELGIUM",
+	"CA": "CANADA",
+	"CH": "SWITZERLAND",
+	"DE": "GERMANY",
+	"DK": "DENMARK",
+	"ES": "SPAIN",
+	"FI": "FINLAND",
+	"FR": "FRANCE",
+	"GB": "UNITED KINGDOM",
+	"IE": "IRELAND",
+	"IT": "ITALY",
+	"LI": "LIECHTENSTEIN",
+	"LU": "LUXEMBOURG",
+	"NL": "NETHERLANDS",
+	"NZ": "NEW ZEALAND",
+	"PL": "POLAND",
+	"PT": "PORTUGAL",
+	"SE": "SWEDEN",
+	"US": "UNITED STATES",
+}
+
+var countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Validate checks that the required fields are present and that the zip code matches
+// the country's format, where it is known. The error's JSON body maps fields to problems.
+func (a Address) Validate() *errors.PingenError {
+	problems := map[string]string{}
+
+	required := map[string]string{
+		"name":    a.Name,
+		"street":  a.Street,
+		"zip":     a.Zip,
+		"city":    a.City,
+		"country": a.Country,
+	}
+	for field, value := range required {
+		if strings.TrimSpace(value) == "" {
+			problems[field] = "is required"
+		}
+	}
+
+	country := strings.ToUpper(a.Country)
+	if a.Country != "" && !countryCode.MatchString(country) {
+		problems["country"] = "must be an ISO 3166-1 alpha-2 code"
+	}
+
+	if layouts[country] == layoutRegion && strings.TrimSpace(a.Region) == "" {
+		problems["region"] = "is required"
+	}
+
+	if pattern, ok := zipPatterns[country]; ok && a.Zip != "" && !pattern.MatchString(strings.ToUpper(a.Zip)) {
+		problems["zip"] = fmt.Sprintf("is not a valid %s postal code", country)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	body, _ := json.Marshal(problems)
+
+	return errors.NewPingenError("Invalid address", string(body), http.StatusUnprocessableEntity, nil)
+}
+
+// Lines lays the address out by the conventions of its country. The country name is
+// added as the last line when the letter is sent from another country.
+func (a Address) Lines(fromCountry string) []string {
+	country := strings.ToUpper(a.Country)
+	lines := []string{a.Name}
+
+	switch layouts[country] {
+	case layoutNumberFirst:
+		lines = append(lines, join(a.Number, a.Street), join(a.City, a.Zip))
+	case layoutPostcodeLine:
+		lines = append(lines, join(a.Number, a.Street), a.City, a.Zip)
+	case layoutRegion:
+		lines = append(lines, join(a.Number, a.Street), join(join(a.City, a.Region), a.Zip))
+	default:
+		lines = append(lines, join(a.Street, a.Number), join(a.Zip, a.City))
+	}
+
+	if country != "" && !strings.EqualFold(country, fromCountry) {
+		lines = append(lines, CountryName(country))
+	}
+
+	return lines
+}
+
+// Format returns Lines joined with newlines, the form Pingen uses for a letter's address.
+func (a Address) Format(fromCountry string) string {
+	return strings.Join(a.Lines(fromCountry), "\n")
+}
+
+// SingleLine returns the address on one line, e.g. for a letter's sender address.
+func (a Address) SingleLine() string {
+	return strings.Join(a.Lines(a.Country), ", ")
+}
+
+// Parse reverses Format for an address in country, e.g. the address Pingen
+// recognised on a letter. Lines it cannot attribute are left out.
+func Parse(formatted, country string) (Address, *errors.PingenError) {
+	country = strings.ToUpper(country)
+
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(formatted, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) > 0 && strings.EqualFold(lines[len(lines)-1], CountryName(country)) {
+		lines = lines[:len(lines)-1]
+	}
+
+	address := Address{Country: country}
+	minimum := 3
+	if layouts[country] == layoutPostcodeLine {
+		minimum = 4
+	}
+
+	if len(lines) < minimum {
+		return Address{}, errors.NewPingenError(
+			fmt.Sprintf("Cannot parse address with %d lines", len(lines)),
+			"",
+			http.StatusUnprocessableEntity,
+			nil,
+		)
+	}
+
+	address.Name = lines[0]
+
+	switch layouts[country] {
+	case layoutNumberFirst:
+		address.Number, address.Street = splitFirst(lines[len(lines)-2])
+		address.City, address.Zip = splitLast(lines[len(lines)-1])
+	case layoutPostcodeLine:
+		address.Number, address.Street = splitFirst(lines[len(lines)-3])
+		address.City = lines[len(lines)-2]
+		address.Zip = lines[len(lines)-1]
+	case layoutRegion:
+		address.Number, address.Street = splitFirst(lines[len(lines)-2])
+		address.City, address.Region, address.Zip = splitRegion(lines[len(lines)-1], country)
+	default:
+		address.Street, address.Number = splitLast(lines[len(lines)-2])
+		address.Zip, address.City = splitZip(lines[len(lines)-1], country)
+	}
+
+	return address, nil
+}
+
+// Map returns the address in the shape the API expects in meta_data. The region is only
+// included when set.
+func (a Address) Map() map[string]string {
+	values := map[string]string{
+		"name":    a.Name,
+		"street":  a.Street,
+		"number":  a.Number,
+		"zip":     a.Zip,
+		"city":    a.City,
+		"country": a.Country,
+	}
+
+	if a.Region != "" {
+		values["region"] = a.Region
+	}
+
+	return values
+}
+
+// MetaData builds the meta_data argument of letters.Create from a recipient and a sender.
+func MetaData(recipient, sender Address) map[string]interface{} {
+	return map[string]interface{}{
+		"recipient": recipient.Map(),
+		"sender":    sender.Map(),
+	}
+}
+
+func FromMap(values map[string]string) Address {
+	return Address{
+		Name:    values["name"],
+		Street:  values["street"],
+		Number:  values["number"],
+		Zip:     values["zip"],
+		City:    values["city"],
+		Region:  values["region"],
+		Country: values["country"],
+	}
+}
+
+// CountryName returns the English name used on international mail, or the code itself if unknown.
+func CountryName(code string) string {
+	if name, ok := countryNames[strings.ToUpper(code)]; ok {
+		return name
+	}
+	return strings.ToUpper(code)
+}
+
+func join(first, second string) string {
+	return strings.TrimSpace(first + " " + second)
+}
+
+// splitFirst splits off a leading house number.
+func splitFirst(line string) (string, string) {
+	if i := strings.Index(line, " "); i > 0 && startsWithDigit(line) {
+		return line[:i], strings.TrimSpace(line[i+1:])
+	}
+	return "", line
+}
+
+// splitLast splits off the last word when it contains a digit, like a house number or zip code.
+func splitLast(line string) (string, string) {
+	if i := strings.LastIndex(line, " "); i > 0 && strings.ContainsAny(line[i+1:], "0123456789") {
+		return strings.TrimSpace(line[:i]), line[i+1:]
+	}
+	return line, ""
+}
+
+// splitZip splits the zip code off the start of a line. Zip codes containing a space,
+// like Dutch ones, are recognised by the country's pattern.
+func splitZip(line, country string) (string, string) {
+	words := strings.Fields(line)
+	if len(words) < 2 {
+		return "", line
+	}
+
+	if pattern, ok := zipPatterns[country]; ok && len(words) > 2 {
+		if zip := words[0] + " " + words[1]; pattern.MatchString(zip) {
+			return zip, strings.Join(words[2:], " ")
+		}
+	}
+
+	return words[0], strings.Join(words[1:], " ")
+}
+
+// splitRegion splits a "CITY ST ZIP" line. Zip codes containing a space, like Canadian
+// ones, are recognised by the country's pattern.
+func splitRegion(line, country string) (string, string, string) {
+	words := strings.Fields(line)
+	if len(words) < 3 {
+		city, zip := splitLast(line)
+		return city, "", zip
+	}
+
+	zipWords := 1
+	if pattern, ok := zipPatterns[country]; ok && len(words) > 3 {
+		if pattern.MatchString(strings.Join(words[len(words)-2:], " ")) {
+			zipWords = 2
+		}
+	}
+
+	zip := strings.Join(words[len(words)-zipWords:], " ")
+	region := words[len(words)-zipWords-1]
+
+	return strings.Join(words[:len(words)-zipWords-1], " "), region, zip
+}
+
+func startsWithDigit(line string) bool {
+	return line != "" && line[0] >= '0' && line[0] <= '9'
+}
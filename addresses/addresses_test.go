@@ -0,0 +1,107 @@
+package addresses_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/addresses"
+	"github.com/stretchr/testify/assert"
+)
+
+var swissAddress = addresses.Address{
+	Name:    "Hans Meier",
+	Street:  "Example street",
+	Number:  "4",
+	Zip:     "8000",
+	City:    "Zürich",
+	Country: "CH",
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, swissAddress.Validate())
+
+	err := addresses.Address{Name: "Hans Meier", Street: "Example street", Zip: "80000", City: "Zürich", Country: "CH"}.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, map[string]interface{}{"zip": "is not a valid CH postal code"}, err.JSONBody)
+
+	err = addresses.Address{Name: "Hans Meier", Country: "Switzerland"}.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"street":  "is required",
+		"zip":     "is required",
+		"city":    "is required",
+		"country": "must be an ISO 3166-1 alpha-2 code",
+	}, err.JSONBody)
+
+	assert.Nil(t, addresses.Address{Name: "Jan de Vries", Street: "Damrak", Number: "1", Zip: "1012 LG", City: "Amsterdam", Country: "NL"}.Validate())
+	assert.Nil(t, addresses.Address{Name: "Sam Smith", Street: "Downing Street", Number: "10", Zip: "SW1A 2AA", City: "London", Country: "gb"}.Validate())
+	assert.Nil(t, addresses.Address{Name: "John Doe", Street: "Main Street", Number: "350", Zip: "62704", City: "Springfield", Region: "IL", Country: "US"}.Validate())
+
+	err = addresses.Address{Name: "John Doe", Street: "Main Street", Number: "350", Zip: "62704", City: "Springfield", Country: "US"}.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, map[string]interface{}{"region": "is required"}, err.JSONBody)
+
+	assert.Nil(t, addresses.Address{Name: "Ana Lima", Street: "Rua Augusta", Number: "2", Zip: "ABC-1", City: "Nowhere", Country: "BR"}.Validate())
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "Hans Meier\nExample street 4\n8000 Zürich", swissAddress.Format("CH"))
+	assert.Equal(t, "Hans Meier\nExample street 4\n8000 Zürich\nSWITZERLAND", swissAddress.Format("DE"))
+	assert.Equal(t, "Hans Meier, Example street 4, 8000 Zürich", swissAddress.SingleLine())
+
+	us := addresses.Address{Name: "John Doe", Street: "Main Street", Number: "350", Zip: "62704", City: "Springfield", Region: "IL", Country: "US"}
+	assert.Equal(t, []string{"John Doe", "350 Main Street", "Springfield IL 62704", "UNITED STATES"}, us.Lines("CH"))
+
+	gb := addresses.Address{Name: "Sam Smith", Street: "Downing Street", Number: "10", Zip: "SW1A 2AA", City: "London", Country: "GB"}
+	assert.Equal(t, []string{"Sam Smith", "10 Downing Street", "London", "SW1A 2AA"}, gb.Lines("GB"))
+}
+
+func TestParse(t *testing.T) {
+	address, err := addresses.Parse("Hans Meier\nExample street 4\n8000 Zürich\nSwitzerland", "CH")
+	assert.Nil(t, err)
+	assert.Equal(t, swissAddress, address)
+
+	nl := addresses.Address{Name: "Jan de Vries", Street: "Damrak", Number: "1", Zip: "1012 LG", City: "Amsterdam", Country: "NL"}
+	address, err = addresses.Parse(nl.Format("CH"), "nl")
+	assert.Nil(t, err)
+	assert.Equal(t, nl, address)
+
+	us := addresses.Address{Name: "John Doe", Street: "Main Street", Number: "350", Zip: "62704", City: "Springfield", Region: "IL", Country: "US"}
+	address, err = addresses.Parse(us.Format("US"), "US")
+	assert.Nil(t, err)
+	assert.Equal(t, us, address)
+
+	ca := addresses.Address{Name: "Jean Tremblay", Street: "Wellington Street", Number: "111", Zip: "K1A 0A9", City: "Ottawa", Region: "ON", Country: "CA"}
+	address, err = addresses.Parse(ca.Format("CH"), "CA")
+	assert.Nil(t, err)
+	assert.Equal(t, ca, address)
+
+	au := addresses.Address{Name: "Jack Wilson", Street: "George Street", Number: "1", Zip: "2000", City: "Sydney South", Region: "NSW", Country: "AU"}
+	address, err = addresses.Parse(au.Format("AU"), "AU")
+	assert.Nil(t, err)
+	assert.Equal(t, au, address)
+
+	gb := addresses.Address{Name: "Sam Smith", Street: "Downing Street", Number: "10", Zip: "SW1A 2AA", City: "London", Country: "GB"}
+	address, err = addresses.Parse(gb.Format("CH"), "GB")
+	assert.Nil(t, err)
+	assert.Equal(t, gb, address)
+
+	_, err = addresses.Parse("Hans Meier\nSwitzerland", "CH")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+}
+
+func TestMapRoundTrip(t *testing.T) {
+	sender := addresses.Address{Name: "ACME AG", Street: "Bahnhofstrasse", Number: "1", Zip: "8001", City: "Zürich", Country: "CH"}
+
+	assert.Equal(t, swissAddress, addresses.FromMap(swissAddress.Map()))
+	assert.NotContains(t, swissAddress.Map(), "region")
+
+	us := addresses.Address{Name: "John Doe", Street: "Main Street", Number: "350", Zip: "62704", City: "Springfield", Region: "IL", Country: "US"}
+	assert.Equal(t, us, addresses.FromMap(us.Map()))
+	assert.Equal(t, map[string]interface{}{
+		"recipient": swissAddress.Map(),
+		"sender":    sender.Map(),
+	}, addresses.MetaData(swissAddress, sender))
+}
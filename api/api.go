@@ -39,6 +39,11 @@ func (r *APIRequestor) PerformPutRequest(
 	url string,
 	file io.Reader,
 ) *errors.PingenError {
+	if r.config.IsDryRun() {
+		r.config.GetDryRunLogger().Printf("[dry-run] PUT %s (file)", uploadPath(url))
+		return nil
+	}
+
 	req, _ := http.NewRequest(http.MethodPut, url, file)
 
 	req.Header.Set("Content-Type", "application/octet-stream")
@@ -99,12 +104,29 @@ func (r *APIRequestor) PerformDeleteRequest(
 }
 
 func (r *APIRequestor) PerformStreamRequest(url string) (io.ReadCloser, *errors.PingenError) {
+	resp, err := r.openStream(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (r *APIRequestor) openStream(url string) (*http.Response, *errors.PingenError) {
 	reqURL := r.preparePath(url, nil)
 	req, _ := http.NewRequest(http.MethodGet, reqURL, nil)
 	req.Header = r.requestHeaders(nil)
 
-	client := &http.Client{Timeout: r.config.GetRequestTimeout()}
-	resp, _ := client.Do(req)
+	client := &http.Client{Timeout: r.config.GetDownloadTimeout()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.NewPingenError(
+			"Internal error",
+			fmt.Sprintf("Failed to send stream request: %v", err.Error()),
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
 
 	if resp.StatusCode != http.StatusOK {
 		defer resp.Body.Close()
@@ -116,7 +138,7 @@ func (r *APIRequestor) PerformStreamRequest(url string) (io.ReadCloser, *errors.
 		)
 	}
 
-	return resp.Body, nil
+	return resp, nil
 }
 
 func (r *APIRequestor) performHTTPRequest(
@@ -127,14 +149,26 @@ func (r *APIRequestor) performHTTPRequest(
 	params map[string]string,
 	target interface{},
 ) (interface{}, *errors.PingenError) {
+	if method != http.MethodGet && r.config.IsDryRun() && !isReadOnlyRequest(method, urlPath) {
+		return r.simulateRequest(method, urlPath, body, target)
+	}
+
 	reqURL := r.preparePath(urlPath, params)
 
 	req, _ := http.NewRequest(method, reqURL, body)
 	req.Header = r.requestHeaders(headers)
 
 	client := &http.Client{Timeout: r.config.GetRequestTimeout()}
-	resp, _ := client.Do(req)
-	defer resp.Body.Close() //nolint:govet
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.NewPingenError(
+			"Internal error",
+			fmt.Sprintf("Failed to send %s request: %v", method, err.Error()),
+			http.StatusInternalServerError,
+			nil,
+		)
+	}
+	defer resp.Body.Close()
 
 	return r.responseHandler.InterpretResponse(resp, target)
 }
@@ -168,3 +202,13 @@ func (r *APIRequestor) requestHeaders(extraHeaders map[string]string) http.Heade
 
 	return headers
 }
+
+// uploadPath strips the upload URL down to its path, leaving out the signature in its query.
+func uploadPath(uploadURL string) string {
+	parsed, err := url.Parse(uploadURL)
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Path
+}
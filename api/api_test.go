@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pingencom/pingen2-sdk-go"
 	"github.com/stretchr/testify/assert"
@@ -235,6 +236,19 @@ func TestPerformGetRequest_Error(t *testing.T) {
 	assert.Equal(t, expectedMessage, err.Error())
 }
 
+func TestPerformGetRequest_NetworkError(t *testing.T) {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL("http://invalid-url")
+	requestor := NewAPIRequestor("dummyToken", config)
+
+	var result map[string]interface{}
+	_, err := requestor.PerformGetRequest("/api/test", &result, nil, nil)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "Internal error", err.Message)
+	assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+}
+
 func TestPerformStreamRequest(t *testing.T) {
 	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -280,3 +294,22 @@ func TestPerformStreamRequest_WrongStatus(t *testing.T) {
 	expectedMessage := "PingenError: Invalid HTTP response (Status Code: 300, Request ID: )"
 	assert.Equal(t, expectedMessage, err.Error())
 }
+
+func TestPerformStreamRequest_Timeout(t *testing.T) {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	config.SetAPIBaseURL(server.URL)
+	config.SetDownloadTimeout(20 * time.Millisecond)
+
+	requestor := NewAPIRequestor("dummyToken", config)
+
+	stream, err := requestor.PerformStreamRequest("/mock-url")
+
+	assert.NotNil(t, err)
+	assert.Nil(t, stream)
+	assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+type DownloadResult struct {
+	ContentType string
+	Size        int64
+	SHA256      string
+}
+
+// PerformDownloadRequest streams url into w and verifies the transfer against the response headers.
+// An empty expectedContentType skips the content type check.
+func (r *APIRequestor) PerformDownloadRequest(
+	url string,
+	w io.Writer,
+	expectedContentType string,
+) (DownloadResult, *errors.PingenError) {
+	resp, err := r.openStream(url)
+	if err != nil {
+		return DownloadResult{}, err
+	}
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get("Content-Type")
+	if expectedContentType != "" && !matchesContentType(contentType, expectedContentType) {
+		return DownloadResult{}, errors.NewPingenError(
+			"Unexpected content type",
+			fmt.Sprintf("Expected %s, got %q", expectedContentType, contentType),
+			resp.StatusCode,
+			convertHeaders(resp.Header),
+		)
+	}
+
+	hash := sha256.New()
+
+	size, copyErr := io.Copy(io.MultiWriter(w, hash), resp.Body)
+	if copyErr != nil {
+		return DownloadResult{}, errors.NewPingenError(
+			"Download failed",
+			fmt.Sprintf("Failed to read response body: %v", copyErr.Error()),
+			http.StatusInternalServerError,
+			convertHeaders(resp.Header),
+		)
+	}
+
+	if resp.ContentLength >= 0 && size != resp.ContentLength {
+		return DownloadResult{}, errors.NewPingenError(
+			"Incomplete download",
+			fmt.Sprintf("Expected %d bytes, received %d", resp.ContentLength, size),
+			resp.StatusCode,
+			convertHeaders(resp.Header),
+		)
+	}
+
+	return DownloadResult{
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// PerformDownloadToFile writes to a temporary file next to path and only renames it into place
+// once the download has been verified, so path never holds a partial file.
+func (r *APIRequestor) PerformDownloadToFile(
+	url, path string,
+	expectedContentType string,
+) (DownloadResult, *errors.PingenError) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return DownloadResult{}, newFileError("Failed to create file", err)
+	}
+
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	result, downloadErr := r.PerformDownloadRequest(url, tmp, expectedContentType)
+	if downloadErr != nil {
+		tmp.Close()
+		return DownloadResult{}, downloadErr
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return DownloadResult{}, newFileError("Failed to write file", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return DownloadResult{}, newFileError("Failed to write file", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return DownloadResult{}, newFileError("Failed to move file into place", err)
+	}
+
+	return result, nil
+}
+
+func matchesContentType(contentType, expected string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == expected
+}
+
+func newFileError(message string, err error) *errors.PingenError {
+	return errors.NewPingenError(
+		fmt.Sprintf("%s: %v", message, err),
+		"",
+		http.StatusInternalServerError,
+		nil,
+	)
+}
+
+func convertHeaders(headers http.Header) map[string]string {
+	converted := make(map[string]string)
+	for key, values := range headers {
+		if len(values) > 0 {
+			converted[key] = values[0]
+		}
+	}
+	return converted
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+const mockPDF = "%PDF-1.4 mock file content"
+
+func setupDownloadServer(t *testing.T, contentType string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/file", r.URL.Path)
+		assert.Equal(t, "Bearer dummyToken", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, mockPDF)
+	}))
+}
+
+func setupDownloadRequestor(apiBaseURL string) *APIRequestor {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(apiBaseURL)
+
+	return NewAPIRequestor("dummyToken", config)
+}
+
+func TestPerformDownloadRequest(t *testing.T) {
+	server := setupDownloadServer(t, "application/pdf; charset=binary")
+	defer server.Close()
+
+	requestor := setupDownloadRequestor(server.URL)
+
+	var buf bytes.Buffer
+	result, err := requestor.PerformDownloadRequest("/file", &buf, "application/pdf")
+
+	sum := sha256.Sum256([]byte(mockPDF))
+
+	assert.Nil(t, err)
+	assert.Equal(t, mockPDF, buf.String())
+	assert.Equal(t, int64(len(mockPDF)), result.Size)
+	assert.Equal(t, "application/pdf; charset=binary", result.ContentType)
+	assert.Equal(t, hex.EncodeToString(sum[:]), result.SHA256)
+}
+
+func TestPerformDownloadRequest_UnexpectedContentType(t *testing.T) {
+	server := setupDownloadServer(t, "text/html")
+	defer server.Close()
+
+	requestor := setupDownloadRequestor(server.URL)
+
+	var buf bytes.Buffer
+	_, err := requestor.PerformDownloadRequest("/file", &buf, "application/pdf")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "Unexpected content type", err.Message)
+	assert.Equal(t, 0, buf.Len())
+}
+
+func TestPerformDownloadRequest_Truncated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Length", "1000")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, mockPDF)
+	}))
+	defer server.Close()
+
+	requestor := setupDownloadRequestor(server.URL)
+
+	var buf bytes.Buffer
+	_, err := requestor.PerformDownloadRequest("/file", &buf, "application/pdf")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "Download failed", err.Message)
+}
+
+func TestPerformDownloadToFile(t *testing.T) {
+	server := setupDownloadServer(t, "application/pdf")
+	defer server.Close()
+
+	requestor := setupDownloadRequestor(server.URL)
+	path := filepath.Join(t.TempDir(), "letter.pdf")
+
+	result, err := requestor.PerformDownloadToFile("/file", path, "application/pdf")
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(len(mockPDF)), result.Size)
+
+	content, readErr := os.ReadFile(path)
+	assert.Nil(t, readErr)
+	assert.Equal(t, mockPDF, string(content))
+
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	assert.Len(t, entries, 1)
+}
+
+func TestPerformDownloadToFile_LeavesNoPartialFile(t *testing.T) {
+	server := setupDownloadServer(t, "text/html")
+	defer server.Close()
+
+	requestor := setupDownloadRequestor(server.URL)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "letter.pdf")
+
+	_, err := requestor.PerformDownloadToFile("/file", path, "application/pdf")
+
+	assert.NotNil(t, err)
+
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 0)
+}
+
+func TestPerformDownloadToFile_MissingDirectory(t *testing.T) {
+	requestor := setupDownloadRequestor("http://invalid-url")
+
+	_, err := requestor.PerformDownloadToFile("/file", filepath.Join(t.TempDir(), "missing", "letter.pdf"), "")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+}
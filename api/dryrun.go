@@ -0,0 +1,162 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/response"
+)
+
+// DryRunIDPrefix marks the IDs of resources that were only simulated in dry-run mode.
+const DryRunIDPrefix = "dry-run-"
+
+// IsDryRunID reports whether id was made up in dry-run mode. The API has no such resource,
+// so it cannot be looked up or checked before an action.
+func IsDryRunID(id string) bool {
+	return strings.HasPrefix(id, DryRunIDPrefix)
+}
+
+func (r *APIRequestor) IsDryRun() bool {
+	return r.config.IsDryRun()
+}
+
+// readOnlyPostPaths end the paths of POST endpoints that only compute a result and change
+// nothing, so dry-run mode still sends them.
+var readOnlyPostPaths = []string{
+	"/letters/price-calculator",
+}
+
+func isReadOnlyRequest(method, urlPath string) bool {
+	if method != http.MethodPost {
+		return false
+	}
+
+	for _, suffix := range readOnlyPostPaths {
+		if strings.HasSuffix(urlPath, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// simulateRequest validates and logs a mutating request that dry-run mode keeps from being sent.
+// Only the method, path and resource type are logged.
+// The synthetic response echoes the payload, so callers can carry on as if the call succeeded.
+func (r *APIRequestor) simulateRequest(
+	method string,
+	urlPath string,
+	body io.Reader,
+	target interface{},
+) (interface{}, *errors.PingenError) {
+	var payload []byte
+	if body != nil {
+		payload, _ = io.ReadAll(body)
+	}
+
+	var request struct {
+		Data struct {
+			ID         string                 `json:"id"`
+			Type       string                 `json:"type"`
+			Attributes map[string]interface{} `json:"attributes"`
+		} `json:"data"`
+	}
+
+	if len(payload) > 0 {
+		if err := json.Unmarshal(payload, &request); err != nil {
+			return nil, errors.NewPingenError(
+				"Invalid request payload",
+				fmt.Sprintf("Dry run %s %s: %v", method, urlPath, err.Error()),
+				http.StatusBadRequest,
+				nil,
+			)
+		}
+	}
+
+	resourceType := request.Data.Type
+	if resourceType == "" {
+		resourceType = dryRunResourceType(urlPath)
+	}
+
+	// The payload is left out: it carries upload signatures and recipients' addresses.
+	r.config.GetDryRunLogger().Printf("[dry-run] %s %s (%s)", method, urlPath, resourceType)
+
+	if target == nil {
+		return &response.DefaultResponse{StatusCode: http.StatusNoContent}, nil
+	}
+
+	attributes := request.Data.Attributes
+	if attributes == nil {
+		attributes = map[string]interface{}{}
+	}
+
+	if status := dryRunStatus(method, urlPath); status != "" {
+		attributes["status"] = status
+	}
+
+	now := time.Now().Format("2006-01-02T15:04:05-0700")
+	if method == http.MethodPost {
+		attributes["created_at"] = now
+	}
+	attributes["updated_at"] = now
+
+	id := request.Data.ID
+	if id == "" {
+		id = newDryRunID()
+	}
+
+	synthetic, _ := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":         id,
+			"type":       resourceType,
+			"attributes": attributes,
+		},
+	})
+
+	if err := json.Unmarshal(synthetic, target); err != nil {
+		return nil, errors.NewPingenError("Failed to parse response body", string(synthetic), http.StatusOK, nil)
+	}
+
+	return target, nil
+}
+
+func dryRunStatus(method, urlPath string) string {
+	switch {
+	case method == http.MethodPost:
+		return "validating"
+	case strings.HasSuffix(urlPath, "/send"):
+		return "submitted"
+	case strings.HasSuffix(urlPath, "/cancel"):
+		return "cancelling"
+	default:
+		return ""
+	}
+}
+
+// dryRunResourceType takes the type from the collection the path addresses,
+// e.g. "letters" for /organisations/{id}/letters and /organisations/{id}/letters/{id}/send.
+func dryRunResourceType(urlPath string) string {
+	segments := strings.Split(strings.Trim(urlPath, "/"), "/")
+
+	switch {
+	case len(segments)%2 == 0:
+		return segments[len(segments)-2]
+	case len(segments) > 3:
+		return segments[len(segments)-3]
+	default:
+		return segments[len(segments)-1]
+	}
+}
+
+func newDryRunID() string {
+	buf := make([]byte, 16)
+	_, _ = rand.Read(buf)
+	return DryRunIDPrefix + hex.EncodeToString(buf)
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/pingencom/pingen2-sdk-go/response"
+	"github.com/stretchr/testify/assert"
+)
+
+type dryRunResource struct {
+	Data struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			Status           string `json:"status"`
+			FileOriginalName string `json:"file_original_name"`
+			CreatedAt        string `json:"created_at"`
+		} `json:"attributes"`
+	} `json:"data"`
+}
+
+func setupDryRun(t *testing.T) (*APIRequestor, *bytes.Buffer, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"id": "real-id", "type": "letters", "attributes": {"status": "valid"}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var logs bytes.Buffer
+
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(server.URL)
+	config.SetDryRun(true)
+	config.SetDryRunLogger(log.New(&logs, "", 0))
+
+	return NewAPIRequestor("dummyToken", config), &logs, &requests
+}
+
+func TestDryRun_PostIsSimulated(t *testing.T) {
+	requestor, logs, requests := setupDryRun(t)
+
+	var target dryRunResource
+	payload := []byte(`{"data": {"type": "letters", "attributes": {"file_original_name": "invoice.pdf", "file_url_signature": "secret-signature", "meta_data": {"recipient": {"name": "Hans Meier"}}}}}`)
+
+	_, err := requestor.PerformPostRequest("/organisations/org/letters", &target, payload, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, *requests)
+	assert.True(t, IsDryRunID(target.Data.ID))
+	assert.Equal(t, "letters", target.Data.Type)
+	assert.Equal(t, "validating", target.Data.Attributes.Status)
+	assert.Equal(t, "invoice.pdf", target.Data.Attributes.FileOriginalName)
+	assert.NotEmpty(t, target.Data.Attributes.CreatedAt)
+	assert.Equal(t, "[dry-run] POST /organisations/org/letters (letters)\n", logs.String())
+}
+
+func TestDryRun_PatchKeepsID(t *testing.T) {
+	requestor, _, requests := setupDryRun(t)
+
+	var target dryRunResource
+	payload := []byte(`{"data": {"id": "letter-1", "type": "letters", "attributes": {"delivery_product": "cheap"}}}`)
+
+	_, err := requestor.PerformPatchRequest("/organisations/org/letters/letter-1/send", &target, payload, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, *requests)
+	assert.Equal(t, "letter-1", target.Data.ID)
+	assert.Equal(t, "submitted", target.Data.Attributes.Status)
+}
+
+func TestDryRun_CancelAndDelete(t *testing.T) {
+	requestor, logs, requests := setupDryRun(t)
+
+	resp, err := requestor.PerformCancelRequest("/organisations/org/letters/letter-1/cancel")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.(*response.DefaultResponse).StatusCode)
+
+	_, err = requestor.PerformDeleteRequest("/organisations/org/letters/letter-1")
+	assert.Nil(t, err)
+
+	assert.Nil(t, requestor.PerformPutRequest("https://upload.example.com/file?signature=secret-signature", strings.NewReader("pdf")))
+
+	assert.Equal(t, 0, *requests)
+	assert.Equal(t, 3, strings.Count(logs.String(), "[dry-run]"))
+	assert.Contains(t, logs.String(), "[dry-run] PATCH /organisations/org/letters/letter-1/cancel (letters)")
+	assert.Contains(t, logs.String(), "[dry-run] PUT /file (file)")
+	assert.NotContains(t, logs.String(), "secret-signature")
+}
+
+func TestDryRun_InvalidPayload(t *testing.T) {
+	requestor, _, _ := setupDryRun(t)
+
+	var target dryRunResource
+	_, err := requestor.PerformPostRequest("/organisations/org/webhooks", &target, []byte(`{"data":`), nil)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, "Invalid request payload", err.Message)
+}
+
+func TestDryRun_GetIsSent(t *testing.T) {
+	requestor, logs, requests := setupDryRun(t)
+
+	var target dryRunResource
+	_, err := requestor.PerformGetRequest("/organisations/org/letters/real-id", &target, nil, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, *requests)
+	assert.Equal(t, "real-id", target.Data.ID)
+	assert.Empty(t, logs.String())
+}
+
+func TestDryRunResourceType(t *testing.T) {
+	assert.Equal(t, "letters", dryRunResourceType("/organisations/org/letters"))
+	assert.Equal(t, "letters", dryRunResourceType("/organisations/org/letters/letter-1"))
+	assert.Equal(t, "letters", dryRunResourceType("/organisations/org/letters/letter-1/send"))
+	assert.Equal(t, "webhooks", dryRunResourceType("/organisations/org/webhooks"))
+	assert.Equal(t, "organisations", dryRunResourceType("/organisations"))
+}
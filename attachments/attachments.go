@@ -0,0 +1,125 @@
+package attachments
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/fileupload"
+)
+
+// Attachments operates on the attachments of a single letter or batch, identified by basePath
+// (e.g. /organisations/{organisationID}/letters/{letterID}/attachments).
+type Attachments struct {
+	basePath     string
+	apiRequestor *api.APIRequestor
+}
+
+type AttachmentResponseData struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Attributes struct {
+		Status           string `json:"status"`
+		FileOriginalName string `json:"file_original_name"`
+		FilePages        int    `json:"file_pages"`
+		FileSize         int    `json:"file_size"`
+		CreatedAt        string `json:"created_at"`
+		UpdatedAt        string `json:"updated_at"`
+	} `json:"attributes"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"links"`
+}
+
+type AttachmentResponse struct {
+	Data     AttachmentResponseData `json:"data"`
+	Included []struct{}             `json:"included"`
+}
+
+type AttachmentCollectionResponse struct {
+	Data     []AttachmentResponseData `json:"data"`
+	Included []struct{}               `json:"included"`
+	Links    struct {
+		First string `json:"first"`
+		Last  string `json:"last"`
+		Prev  string `json:"prev"`
+		Next  string `json:"next"`
+		Self  string `json:"self"`
+	} `json:"links"`
+	Meta struct {
+		CurrentPage int `json:"current_page"`
+		LastPage    int `json:"last_page"`
+		PerPage     int `json:"per_page"`
+		From        int `json:"from"`
+		To          int `json:"to"`
+		Total       int `json:"total"`
+	} `json:"meta"`
+}
+
+func NewAttachments(basePath string, apiRequestor *api.APIRequestor) *Attachments {
+	return &Attachments{
+		basePath:     basePath,
+		apiRequestor: apiRequestor,
+	}
+}
+
+func (a *Attachments) GetCollection(params map[string]string, headers map[string]string) (AttachmentCollectionResponse, *errors.PingenError) {
+	var response AttachmentCollectionResponse
+
+	_, err := a.apiRequestor.PerformGetRequest(a.basePath, &response, params, headers)
+	if err != nil {
+		return AttachmentCollectionResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (a *Attachments) UploadAndCreate(pathToFile, fileOriginalName string) (AttachmentResponse, *errors.PingenError) {
+	fileUpload := fileupload.NewFileUpload(a.apiRequestor)
+
+	fileResponse, err := fileUpload.RequestFileUpload()
+	if err != nil {
+		return AttachmentResponse{}, err
+	}
+
+	err = fileUpload.PutFile(pathToFile, fileResponse.Data.Attributes.URL)
+	if err != nil {
+		return AttachmentResponse{}, err
+	}
+
+	return a.Create(
+		fileResponse.Data.Attributes.URL,
+		fileResponse.Data.Attributes.URLSignature,
+		fileOriginalName,
+	)
+}
+
+func (a *Attachments) Create(fileURL, fileSignature, fileOriginalName string) (AttachmentResponse, *errors.PingenError) {
+	payload := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "attachments",
+			"attributes": map[string]string{
+				"file_original_name": fileOriginalName,
+				"file_url":           fileURL,
+				"file_url_signature": fileSignature,
+			},
+		},
+	}
+
+	data, _ := json.Marshal(payload)
+
+	var response AttachmentResponse
+
+	_, err := a.apiRequestor.PerformPostRequest(a.basePath, &response, data, nil)
+	if err != nil {
+		return AttachmentResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (a *Attachments) Delete(attachmentID string) (interface{}, *errors.PingenError) {
+	url := fmt.Sprintf("%s/%s", a.basePath, attachmentID)
+	return a.apiRequestor.PerformDeleteRequest(url)
+}
@@ -0,0 +1,203 @@
+package attachments_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/attachments"
+	"github.com/stretchr/testify/assert"
+)
+
+const basePath = "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/letterxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/attachments"
+
+const mockAttachmentResponse = `{
+	"data": {
+		"id": "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1",
+		"type": "attachments",
+		"attributes": {
+			"status": "valid",
+			"file_original_name": "terms.pdf",
+			"file_pages": 2,
+			"file_size": 1024,
+			"created_at": "2020-11-19T09:42:48+0100",
+			"updated_at": "2020-11-19T09:42:48+0100"
+		},
+		"links": {
+			"self": "string"
+		}
+	},
+	"included": []
+}`
+
+func setupUnauthorizedServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Header().Set("X-Request-Id", "requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		responseJSON := `{"error":"invalid_client","error_description":"Client authentication failed","message":"Client authentication failed"}`
+		_, _ = w.Write([]byte(responseJSON))
+	}))
+}
+
+func setupAttachments(apiBaseURL string) *attachments.Attachments {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(apiBaseURL)
+	apiRequestor := api.NewAPIRequestor("dummyToken", config)
+
+	return attachments.NewAttachments(basePath, apiRequestor)
+}
+
+func TestGetCollection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, basePath, r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"data": [
+				{"id": "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "type": "attachments", "attributes": {"file_original_name": "terms.pdf", "file_pages": 2}},
+				{"id": "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx2", "type": "attachments", "attributes": {"file_original_name": "flyer.pdf", "file_pages": 1}}
+			],
+			"included": [],
+			"links": {"first": "first-link", "last": "last-link"},
+			"meta": {"current_page": 1, "last_page": 1, "per_page": 10, "total": 2}
+		}`))
+	}))
+	defer server.Close()
+
+	attachmentClient := setupAttachments(server.URL)
+
+	resp, err := attachmentClient.GetCollection(map[string]string{}, map[string]string{})
+
+	assert.Nil(t, err)
+	assert.Len(t, resp.Data, 2)
+	assert.Equal(t, "terms.pdf", resp.Data[0].Attributes.FileOriginalName)
+	assert.Equal(t, "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx2", resp.Data[1].ID)
+	assert.Equal(t, 2, resp.Meta.Total)
+}
+
+func TestGetCollection_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	attachmentClient := setupAttachments(server.URL)
+
+	_, err := attachmentClient.GetCollection(nil, nil)
+
+	assert.NotNil(t, err)
+	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
+	assert.Equal(t, expectedMessage, err.Error())
+}
+
+func TestUploadAndCreate(t *testing.T) {
+	var server *httptest.Server
+
+	counter := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if counter == 0 {
+			assert.Equal(t, "/file-upload", r.URL.Path)
+			assert.Equal(t, http.MethodGet, r.Method)
+			w.WriteHeader(http.StatusOK)
+			mockUploadResponse := fmt.Sprintf(`{
+				"data": {
+					"attributes": {
+						"url": "%s/upload",
+						"url_signature": "mock-signature"
+					}
+				}
+			}`, server.URL)
+
+			_, _ = w.Write([]byte(mockUploadResponse))
+		}
+
+		if counter == 1 {
+			assert.Equal(t, "/upload", r.URL.Path)
+			assert.Equal(t, http.MethodPut, r.Method)
+			w.WriteHeader(http.StatusNoContent)
+		}
+
+		if counter == 2 {
+			assert.Equal(t, basePath, r.URL.Path)
+			assert.Equal(t, http.MethodPost, r.Method)
+
+			body, _ := io.ReadAll(r.Body)
+			expectedPayload := fmt.Sprintf(`{
+				"data": {
+					"type": "attachments",
+					"attributes": {
+						"file_original_name": "terms.pdf",
+						"file_url": "%s/upload",
+						"file_url_signature": "mock-signature"
+					}
+				}
+			}`, server.URL)
+			assert.JSONEq(t, expectedPayload, string(body))
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(mockAttachmentResponse))
+		}
+
+		counter++
+	}))
+	defer server.Close()
+
+	attachmentClient := setupAttachments(server.URL)
+
+	resp, err := attachmentClient.UploadAndCreate("testFile.pdf", "terms.pdf")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", resp.Data.ID)
+	assert.Equal(t, 1024, resp.Data.Attributes.FileSize)
+	assert.Equal(t, 3, counter)
+}
+
+func TestUploadAndCreate_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	attachmentClient := setupAttachments(server.URL)
+
+	_, err := attachmentClient.UploadAndCreate("testFile.pdf", "terms.pdf")
+
+	assert.NotNil(t, err)
+	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
+	assert.Equal(t, expectedMessage, err.Error())
+}
+
+func TestUploadAndCreate_MissingFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"attributes": {"url": "http://localhost/upload", "url_signature": "mock-signature"}}}`))
+	}))
+	defer server.Close()
+
+	attachmentClient := setupAttachments(server.URL)
+
+	_, err := attachmentClient.UploadAndCreate("missing.pdf", "terms.pdf")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "Failed to open file", err.Message)
+}
+
+func TestDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, basePath+"/attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
+		assert.Equal(t, http.MethodDelete, r.Method)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	attachmentClient := setupAttachments(server.URL)
+
+	resp, err := attachmentClient.Delete("attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, resp)
+}
@@ -0,0 +1,37 @@
+package batches
+
+import (
+	"fmt"
+
+	"github.com/pingencom/pingen2-sdk-go/attachments"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+func (b *Batches) attachments(batchID string) *attachments.Attachments {
+	basePath := fmt.Sprintf("/organisations/%s/batches/%s/attachments", b.organisationID, batchID)
+	return attachments.NewAttachments(basePath, b.apiRequestor)
+}
+
+func (b *Batches) UploadAndAddAttachment(
+	batchID, pathToFile, fileOriginalName string,
+) (attachments.AttachmentResponse, *errors.PingenError) {
+	return b.attachments(batchID).UploadAndCreate(pathToFile, fileOriginalName)
+}
+
+func (b *Batches) AddAttachment(
+	batchID, fileURL, fileSignature, fileOriginalName string,
+) (attachments.AttachmentResponse, *errors.PingenError) {
+	return b.attachments(batchID).Create(fileURL, fileSignature, fileOriginalName)
+}
+
+func (b *Batches) GetAttachments(
+	batchID string,
+	params map[string]string,
+	suppliedHeaders map[string]string,
+) (attachments.AttachmentCollectionResponse, *errors.PingenError) {
+	return b.attachments(batchID).GetCollection(params, suppliedHeaders)
+}
+
+func (b *Batches) DeleteAttachment(batchID, attachmentID string) (interface{}, *errors.PingenError) {
+	return b.attachments(batchID).Delete(attachmentID)
+}
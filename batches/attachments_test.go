@@ -0,0 +1,62 @@
+package batches_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchAttachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/attachments", r.URL.Path)
+
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"file_url_signature":"signature"`)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"data": {"id": "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "type": "attachments"}}`))
+		case http.MethodGet:
+			assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/attachments", r.URL.Path)
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"data": [{"id": "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "type": "attachments"}]}`))
+		case http.MethodDelete:
+			assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/attachments/attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
+
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	batchClient := setupBatch(server.URL)
+
+	created, err := batchClient.AddAttachment("test-batch-id", "https://s3.example.com/file", "signature", "terms.pdf")
+	assert.Nil(t, err)
+	assert.Equal(t, "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", created.Data.ID)
+
+	collection, err := batchClient.GetAttachments("test-batch-id", nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, collection.Data, 1)
+
+	deleted, err := batchClient.DeleteAttachment("test-batch-id", "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+	assert.Nil(t, err)
+	assert.NotNil(t, deleted)
+}
+
+func TestBatchUploadAndAddAttachment_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	batchClient := setupBatch(server.URL)
+
+	_, err := batchClient.UploadAndAddAttachment("test-batch-id", "test.zip", "terms.pdf")
+
+	assert.NotNil(t, err)
+	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
+	assert.Equal(t, expectedMessage, err.Error())
+}
@@ -0,0 +1,159 @@
+package batches
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+)
+
+type LetterFilter struct {
+	// Statuses restricts the listing to letters in any of these statuses. Empty lists all letters.
+	Statuses []letters.Status
+	// PageSize is the number of letters fetched per request. Zero uses the API default.
+	PageSize int
+}
+
+func (f LetterFilter) matches(status letters.Status) bool {
+	if len(f.Statuses) == 0 {
+		return true
+	}
+
+	for _, wanted := range f.Statuses {
+		if wanted == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetLetters returns one page of the letters that belong to the batch, starting at page 1.
+func (b *Batches) GetLetters(batchID string, filter LetterFilter, page int) (letters.LetterCollectionResponse, *errors.PingenError) {
+	conditions := []map[string]interface{}{{"batch_id": batchID}}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]map[string]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, map[string]string{"status": string(status)})
+		}
+		conditions = append(conditions, map[string]interface{}{"or": statuses})
+	}
+
+	encoded, _ := json.Marshal(map[string]interface{}{"and": conditions})
+
+	params := map[string]string{
+		"filter":       string(encoded),
+		"page[number]": strconv.Itoa(page),
+	}
+
+	if filter.PageSize > 0 {
+		params["page[limit]"] = strconv.Itoa(filter.PageSize)
+	}
+
+	response, err := b.letterClient().GetCollection(params, nil)
+	if err != nil {
+		return letters.LetterCollectionResponse{}, err
+	}
+
+	// Only keep what was asked for, even if the API ignored part of the filter.
+	matching := response.Data[:0]
+	for _, letter := range response.Data {
+		if letter.Relationships.Batch.Data.ID == batchID && filter.matches(letters.Status(letter.Attributes.Status)) {
+			matching = append(matching, letter)
+		}
+	}
+	response.Data = matching
+
+	return response, nil
+}
+
+// Letters iterates over every letter in the batch that matches filter, fetching pages as needed.
+func (b *Batches) Letters(batchID string, filter LetterFilter) *LetterIterator {
+	return &LetterIterator{batches: b, batchID: batchID, filter: filter}
+}
+
+// GetLetter returns a letter of the batch, or a 404 if the letter belongs elsewhere.
+func (b *Batches) GetLetter(batchID, letterID string) (letters.LetterResponse, *errors.PingenError) {
+	letter, err := b.letterClient().GetDetails(letterID, nil, nil)
+	if err != nil {
+		return letters.LetterResponse{}, err
+	}
+
+	if letter.Data.Relationships.Batch.Data.ID != batchID {
+		return letters.LetterResponse{}, errors.NewPingenError(
+			fmt.Sprintf("Letter %s does not belong to batch %s", letterID, batchID),
+			"",
+			http.StatusNotFound,
+			nil,
+		)
+	}
+
+	return letter, nil
+}
+
+// CancelLetter cancels a single letter of the batch and leaves the others alone.
+func (b *Batches) CancelLetter(batchID, letterID string) (interface{}, *errors.PingenError) {
+	if _, err := b.GetLetter(batchID, letterID); err != nil {
+		return nil, err
+	}
+
+	return b.letterClient().Cancel(letterID)
+}
+
+func (b *Batches) letterClient() *letters.Letters {
+	return letters.NewLetters(b.organisationID, b.apiRequestor)
+}
+
+// LetterIterator pages through a batch's letters:
+//
+//	it := batchClient.Letters(batchID, batches.LetterFilter{Statuses: []letters.Status{letters.StatusInvalid}})
+//	for it.Next() {
+//		letter := it.Letter()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type LetterIterator struct {
+	batches  *Batches
+	batchID  string
+	filter   LetterFilter
+	page     int
+	lastPage int
+	buffer   []letters.LetterCollectionItem
+	current  letters.LetterCollectionItem
+	err      *errors.PingenError
+}
+
+// Next advances to the next letter. It returns false when there are no more letters or a request failed.
+func (it *LetterIterator) Next() bool {
+	for len(it.buffer) == 0 {
+		if it.err != nil || (it.page > 0 && it.page >= it.lastPage) {
+			return false
+		}
+
+		it.page++
+		response, err := it.batches.GetLetters(it.batchID, it.filter, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.buffer = response.Data
+		it.lastPage = response.Meta.LastPage
+	}
+
+	it.current, it.buffer = it.buffer[0], it.buffer[1:]
+
+	return true
+}
+
+func (it *LetterIterator) Letter() letters.LetterCollectionItem {
+	return it.current
+}
+
+func (it *LetterIterator) Err() *errors.PingenError {
+	return it.err
+}
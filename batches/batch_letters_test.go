@@ -0,0 +1,124 @@
+package batches_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/batches"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+func letterItem(id, status, batchID string) string {
+	return fmt.Sprintf(
+		`{"id": "%s", "type": "letters", "attributes": {"status": "%s"}, "relationships": {"batch": {"data": {"id": "%s", "type": "batches"}}}, "meta": {"abilities": {"self": {"cancel": "ok"}}}}`,
+		id, status, batchID,
+	)
+}
+
+func TestGetLetters_Iterator(t *testing.T) {
+	pages := map[int][]string{
+		1: {letterItem("letter-1", "sent", "test-batch-id"), letterItem("letter-2", "invalid", "test-batch-id")},
+		2: {letterItem("letter-3", "invalid", "test-batch-id"), letterItem("letter-4", "invalid", "other-batch")},
+		3: {},
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("page[limit]"))
+
+		var filter map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(r.URL.Query().Get("filter")), &filter))
+		assert.Equal(t, map[string]interface{}{"and": []interface{}{
+			map[string]interface{}{"batch_id": "test-batch-id"},
+			map[string]interface{}{"or": []interface{}{map[string]interface{}{"status": "invalid"}}},
+		}}, filter)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page[number]"))
+		items := "["
+		for i, item := range pages[page] {
+			if i > 0 {
+				items += ","
+			}
+			items += item
+		}
+		items += "]"
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"data": %s, "meta": {"current_page": %d, "last_page": 2}}`, items, page)
+	}))
+	defer server.Close()
+
+	it := setupBatch(server.URL).Letters("test-batch-id", batches.LetterFilter{
+		Statuses: []letters.Status{letters.StatusInvalid},
+		PageSize: 50,
+	})
+
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Letter().ID)
+	}
+
+	assert.Nil(t, it.Err())
+	assert.Equal(t, []string{"letter-2", "letter-3"}, ids)
+	assert.Equal(t, 2, requests)
+	assert.False(t, it.Next())
+}
+
+func TestGetLetters_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"errors": [{"title": "Server error"}]}`))
+	}))
+	defer server.Close()
+
+	it := setupBatch(server.URL).Letters("test-batch-id", batches.LetterFilter{})
+
+	assert.False(t, it.Next())
+	assert.NotNil(t, it.Err())
+	assert.Equal(t, http.StatusInternalServerError, it.Err().StatusCode)
+}
+
+func TestGetLetter_Membership(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/letter-1":
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, letterItem("letter-1", "valid", "test-batch-id"))
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/letter-2":
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, letterItem("letter-2", "valid", "other-batch"))
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/letter-1/cancel":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	batchClient := setupBatch(server.URL)
+
+	letter, err := batchClient.GetLetter("test-batch-id", "letter-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "letter-1", letter.Data.ID)
+
+	_, err = batchClient.GetLetter("test-batch-id", "letter-2")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusNotFound, err.StatusCode)
+
+	methods = nil
+	_, err = batchClient.CancelLetter("test-batch-id", "letter-2")
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"GET /organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/letter-2"}, methods)
+
+	_, err = batchClient.CancelLetter("test-batch-id", "letter-1")
+	assert.Nil(t, err)
+	assert.Contains(t, methods, "PATCH /organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/letter-1/cancel")
+}
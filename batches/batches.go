@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/deliveryproducts"
 	"github.com/pingencom/pingen2-sdk-go/errors"
 	"github.com/pingencom/pingen2-sdk-go/fileupload"
 )
@@ -61,8 +62,9 @@ const (
 )
 
 type Batches struct {
-	organisationID string
-	apiRequestor   *api.APIRequestor
+	organisationID  string
+	apiRequestor    *api.APIRequestor
+	deliveryCatalog *deliveryproducts.Catalog
 }
 
 type BatchResponse struct {
@@ -237,6 +239,27 @@ func (b *Batches) UploadAndCreateBatch(
 	groupingType GroupingType, groupingOptionsSplitType SplitType,
 	groupingOptionsSplitSize *int,
 	groupingOptionsSplitSeparator *string, groupingOptionsSplitPosition *SplitPosition,
+) (BatchResponse, *errors.PingenError) {
+	return b.upload(pathToFile, func(fileURL, fileURLSignature string) (BatchResponse, *errors.PingenError) {
+		return b.CreateBatch(
+			fileURL,
+			fileURLSignature,
+			name,
+			icon,
+			fileOriginalName,
+			addressPosition,
+			groupingType,
+			groupingOptionsSplitType,
+			groupingOptionsSplitSize,
+			groupingOptionsSplitSeparator,
+			groupingOptionsSplitPosition,
+		)
+	})
+}
+
+func (b *Batches) upload(
+	pathToFile string,
+	create func(fileURL, fileURLSignature string) (BatchResponse, *errors.PingenError),
 ) (BatchResponse, *errors.PingenError) {
 	fileUpload := fileupload.NewFileUpload(b.apiRequestor)
 
@@ -250,19 +273,7 @@ func (b *Batches) UploadAndCreateBatch(
 		return BatchResponse{}, err
 	}
 
-	return b.CreateBatch(
-		fileResponse.Data.Attributes.URL,
-		fileResponse.Data.Attributes.URLSignature,
-		name,
-		icon,
-		fileOriginalName,
-		addressPosition,
-		groupingType,
-		groupingOptionsSplitType,
-		groupingOptionsSplitSize,
-		groupingOptionsSplitSeparator,
-		groupingOptionsSplitPosition,
-	)
+	return create(fileResponse.Data.Attributes.URL, fileResponse.Data.Attributes.URLSignature)
 }
 
 func (b *Batches) CreateBatch(
@@ -294,6 +305,10 @@ func (b *Batches) CreateBatch(
 		attributes["grouping_options_split_position"] = string(*groupingOptionsSplitPosition)
 	}
 
+	return b.createBatch(attributes)
+}
+
+func (b *Batches) createBatch(attributes map[string]interface{}) (BatchResponse, *errors.PingenError) {
 	payload := map[string]interface{}{
 		"data": map[string]interface{}{
 			"type":       "batches",
@@ -315,6 +330,17 @@ func (b *Batches) CreateBatch(
 }
 
 func (b *Batches) SendBatch(batchID string, deliveryProducts map[string]string, printMode, printSpectrum string) (BatchResponse, *errors.PingenError) {
+	if b.deliveryCatalog != nil {
+		err := b.deliveryCatalog.ValidateDeliveryProducts(
+			deliveryProducts,
+			deliveryproducts.PrintMode(printMode),
+			deliveryproducts.PrintSpectrum(printSpectrum),
+		)
+		if err != nil {
+			return BatchResponse{}, err
+		}
+	}
+
 	payload := map[string]interface{}{
 		"data": map[string]interface{}{
 			"id":   batchID,
@@ -340,6 +366,12 @@ func (b *Batches) SendBatch(batchID string, deliveryProducts map[string]string,
 	return response, nil
 }
 
+// SetDeliveryCatalog makes SendBatch reject delivery products the catalog does not allow
+// before anything is sent. Nil, the default, leaves validation to the API.
+func (b *Batches) SetDeliveryCatalog(catalog *deliveryproducts.Catalog) {
+	b.deliveryCatalog = catalog
+}
+
 func (b *Batches) CancelBatch(batchID string) (interface{}, *errors.PingenError) {
 	url := fmt.Sprintf("/organisations/%s/batches/%s/cancel", b.organisationID, batchID)
 	return b.apiRequestor.PerformCancelRequest(url)
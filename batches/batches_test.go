@@ -10,6 +10,7 @@ import (
 	"github.com/pingencom/pingen2-sdk-go"
 	"github.com/pingencom/pingen2-sdk-go/api"
 	"github.com/pingencom/pingen2-sdk-go/batches"
+	"github.com/pingencom/pingen2-sdk-go/deliveryproducts"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -537,6 +538,34 @@ func TestSendBatch(t *testing.T) {
 	assert.Equal(t, "test-batch-id", resp.Data.ID)
 }
 
+func TestSendBatch_DeliveryCatalog(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"delivery_products":{"CH":"postag_a","DE":"dpag_standard"}`)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockBatchResponse))
+	}))
+	defer server.Close()
+
+	batchClient := setupBatch(server.URL)
+	batchClient.SetDeliveryCatalog(deliveryproducts.DefaultCatalog())
+
+	_, err := batchClient.SendBatch("test-batch-id", map[string]string{"CH": "postag_a", "DE": "postag_a"}, "simplex", "color")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, 0, requests)
+
+	_, err = batchClient.SendBatch("test-batch-id", map[string]string{"CH": "postag_a", "DE": "dpag_standard"}, "simplex", "color")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+}
+
 func TestSendBatch_Error(t *testing.T) {
 	server := setupUnauthorizedServer()
 	defer server.Close()
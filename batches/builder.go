@@ -0,0 +1,247 @@
+package batches
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/fileupload"
+)
+
+type builderFile struct {
+	name string
+	open func() (io.ReadCloser, error)
+}
+
+// Builder packages PDFs into a ZIP and creates a batch from it. Errors from adding files
+// are kept and reported by Create, so calls can be chained.
+type Builder struct {
+	batches         *Batches
+	name            string
+	icon            Icon
+	addressPosition AddressPosition
+	split           Split
+	streaming       bool
+	files           []builderFile
+	err             *errors.PingenError
+}
+
+// NewBuilder starts a batch that puts every file into its own letter, with the address on the left.
+func (b *Batches) NewBuilder(name string) *Builder {
+	return &Builder{
+		batches:         b,
+		name:            name,
+		icon:            IconDocument,
+		addressPosition: AddressPositionLeft,
+		split:           SplitByFile{},
+	}
+}
+
+func (bb *Builder) Icon(icon Icon) *Builder {
+	bb.icon = icon
+	return bb
+}
+
+func (bb *Builder) AddressPosition(addressPosition AddressPosition) *Builder {
+	bb.addressPosition = addressPosition
+	return bb
+}
+
+func (bb *Builder) Split(split Split) *Builder {
+	bb.split = split
+	return bb
+}
+
+// Streaming builds the ZIP in a temporary file instead of in memory, for batches too large to hold.
+func (bb *Builder) Streaming(enabled bool) *Builder {
+	bb.streaming = enabled
+	return bb
+}
+
+func (bb *Builder) AddFile(path string) *Builder {
+	if _, err := os.Stat(path); err != nil {
+		bb.fail("Failed to add file", err)
+		return bb
+	}
+
+	bb.files = append(bb.files, builderFile{
+		name: filepath.Base(path),
+		open: func() (io.ReadCloser, error) { return os.Open(path) },
+	})
+
+	return bb
+}
+
+// AddReader adds a PDF under name. The reader is consumed when the ZIP is built.
+func (bb *Builder) AddReader(name string, reader io.Reader) *Builder {
+	bb.files = append(bb.files, builderFile{
+		name: name,
+		open: func() (io.ReadCloser, error) { return io.NopCloser(reader), nil },
+	})
+
+	return bb
+}
+
+// AddDirectory adds every PDF directly inside dir, in name order.
+func (bb *Builder) AddDirectory(dir string) *Builder {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		bb.fail("Failed to read directory", err)
+		return bb
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".pdf") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		bb.AddFile(filepath.Join(dir, name))
+	}
+
+	return bb
+}
+
+// WriteZip writes the ZIP that Create would upload.
+func (bb *Builder) WriteZip(w io.Writer) *errors.PingenError {
+	if bb.err != nil {
+		return bb.err
+	}
+
+	if len(bb.files) == 0 {
+		return errors.NewPingenError("Batch has no files", "", http.StatusBadRequest, nil)
+	}
+
+	archive := zip.NewWriter(w)
+	used := map[string]bool{}
+
+	for _, file := range bb.files {
+		if err := bb.writeEntry(archive, file, uniqueName(file.name, used)); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return newBuilderError("Failed to write ZIP", err)
+	}
+
+	return nil
+}
+
+// Create builds the ZIP, uploads it and creates the batch.
+func (bb *Builder) Create() (BatchResponse, *errors.PingenError) {
+	if err := validateSplit(bb.split); err != nil {
+		return BatchResponse{}, err
+	}
+
+	var upload func(url string) *errors.PingenError
+	fileUpload := fileupload.NewFileUpload(bb.batches.apiRequestor)
+
+	if bb.streaming {
+		tmp, err := os.CreateTemp("", "pingen-batch-*.zip")
+		if err != nil {
+			return BatchResponse{}, newBuilderError("Failed to create temporary file", err)
+		}
+		defer os.Remove(tmp.Name())
+
+		pErr := bb.WriteZip(tmp)
+		if closeErr := tmp.Close(); pErr == nil && closeErr != nil {
+			pErr = newBuilderError("Failed to write ZIP", closeErr)
+		}
+		if pErr != nil {
+			return BatchResponse{}, pErr
+		}
+
+		upload = func(url string) *errors.PingenError {
+			return fileUpload.PutFile(tmp.Name(), url)
+		}
+	} else {
+		var buf bytes.Buffer
+		if err := bb.WriteZip(&buf); err != nil {
+			return BatchResponse{}, err
+		}
+
+		upload = func(url string) *errors.PingenError {
+			return bb.batches.apiRequestor.PerformPutRequest(url, &buf)
+		}
+	}
+
+	fileResponse, err := fileUpload.RequestFileUpload()
+	if err != nil {
+		return BatchResponse{}, err
+	}
+
+	if err := upload(fileResponse.Data.Attributes.URL); err != nil {
+		return BatchResponse{}, err
+	}
+
+	return bb.batches.CreateBatchWithSplit(
+		fileResponse.Data.Attributes.URL,
+		fileResponse.Data.Attributes.URLSignature,
+		bb.name,
+		bb.icon,
+		bb.name+".zip",
+		bb.addressPosition,
+		bb.split,
+	)
+}
+
+func (bb *Builder) writeEntry(archive *zip.Writer, file builderFile, name string) *errors.PingenError {
+	reader, err := file.open()
+	if err != nil {
+		return newBuilderError(fmt.Sprintf("Failed to open %s", file.name), err)
+	}
+	defer reader.Close()
+
+	entry, err := archive.Create(name)
+	if err != nil {
+		return newBuilderError("Failed to write ZIP", err)
+	}
+
+	if _, err := io.Copy(entry, reader); err != nil {
+		return newBuilderError(fmt.Sprintf("Failed to read %s", file.name), err)
+	}
+
+	return nil
+}
+
+func (bb *Builder) fail(message string, err error) {
+	if bb.err == nil {
+		bb.err = newBuilderError(message, err)
+	}
+}
+
+// uniqueName gives every file a .pdf name that is not yet taken in the ZIP.
+func uniqueName(name string, used map[string]bool) string {
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	if base == "" || base == "." {
+		base = "letter"
+	}
+
+	candidate := base + ".pdf"
+	for n := 2; used[strings.ToLower(candidate)]; n++ {
+		candidate = fmt.Sprintf("%s-%d.pdf", base, n)
+	}
+	used[strings.ToLower(candidate)] = true
+
+	return candidate
+}
+
+func newBuilderError(message string, err error) *errors.PingenError {
+	return errors.NewPingenError(
+		fmt.Sprintf("%s: %v", message, err),
+		"",
+		http.StatusInternalServerError,
+		nil,
+	)
+}
@@ -0,0 +1,143 @@
+package batches_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/batches"
+	"github.com/stretchr/testify/assert"
+)
+
+func zipEntries(t *testing.T, data []byte) map[string]string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.Nil(t, err)
+
+	entries := map[string]string{}
+	for _, file := range archive.File {
+		reader, _ := file.Open()
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+		entries[file.Name] = string(content)
+	}
+
+	return entries
+}
+
+func writePDFs(t *testing.T, names ...string) string {
+	dir := t.TempDir()
+	for _, name := range names {
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte("%PDF-"+name), 0o600))
+	}
+
+	return dir
+}
+
+func TestBuilder_WriteZip(t *testing.T) {
+	dir := writePDFs(t, "b.pdf", "a.pdf", "notes.txt")
+
+	builder := setupBatch("http://localhost").NewBuilder("Invoices").
+		AddDirectory(dir).
+		AddFile(filepath.Join(dir, "a.pdf")).
+		AddReader("generated", strings.NewReader("%PDF-generated"))
+
+	var buf bytes.Buffer
+	assert.Nil(t, builder.WriteZip(&buf))
+
+	assert.Equal(t, map[string]string{
+		"a.pdf":         "%PDF-a.pdf",
+		"b.pdf":         "%PDF-b.pdf",
+		"a-2.pdf":       "%PDF-a.pdf",
+		"generated.pdf": "%PDF-generated",
+	}, zipEntries(t, buf.Bytes()))
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := setupBatch("http://localhost").NewBuilder("Empty").WriteZip(&buf)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+
+	err = setupBatch("http://localhost").NewBuilder("Missing").
+		AddFile("does-not-exist.pdf").
+		AddReader("fine.pdf", strings.NewReader("%PDF-")).
+		WriteZip(&buf)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Message, "Failed to add file")
+
+	_, err = setupBatch("http://localhost").NewBuilder("Missing").AddDirectory("does-not-exist").Create()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Message, "Failed to read directory")
+}
+
+func TestBuilder_Create(t *testing.T) {
+	for _, streaming := range []bool{false, true} {
+		t.Run(fmt.Sprintf("streaming=%v", streaming), func(t *testing.T) {
+			dir := writePDFs(t, "a.pdf", "b.pdf")
+
+			var uploaded []byte
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/file-upload":
+					w.WriteHeader(http.StatusOK)
+					_, _ = fmt.Fprintf(w, `{"data": {"attributes": {"url": "%s/upload", "url_signature": "signature"}}}`, server.URL)
+				case "/upload":
+					assert.Equal(t, http.MethodPut, r.Method)
+					uploaded, _ = io.ReadAll(r.Body)
+					w.WriteHeader(http.StatusOK)
+				case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches":
+					body, _ := io.ReadAll(r.Body)
+					assert.Contains(t, string(body), `"name":"Invoices"`)
+					assert.Contains(t, string(body), `"file_original_name":"Invoices.zip"`)
+					assert.Contains(t, string(body), `"icon":"receipt"`)
+					assert.Contains(t, string(body), `"address_position":"right"`)
+					assert.Contains(t, string(body), `"grouping_type":"merge"`)
+					assert.Contains(t, string(body), `"grouping_options_split_type":"page"`)
+					assert.Contains(t, string(body), `"grouping_options_split_size":2`)
+					assert.NotContains(t, string(body), `grouping_options_split_separator`)
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(mockBatchResponse))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			resp, err := setupBatch(server.URL).NewBuilder("Invoices").
+				Icon(batches.IconReceipt).
+				AddressPosition(batches.AddressPositionRight).
+				Split(batches.SplitByPage{Size: 2}).
+				Streaming(streaming).
+				AddDirectory(dir).
+				Create()
+
+			assert.Nil(t, err)
+			assert.Equal(t, "test-batch-id", resp.Data.ID)
+			assert.Equal(t, map[string]string{"a.pdf": "%PDF-a.pdf", "b.pdf": "%PDF-b.pdf"}, zipEntries(t, uploaded))
+		})
+	}
+}
+
+func TestBuilder_InvalidSplit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	_, err := setupBatch(server.URL).NewBuilder("Invoices").
+		AddReader("a.pdf", strings.NewReader("%PDF-")).
+		Split(batches.SplitByCustom{Separator: "%%SPLIT%%"}).
+		Create()
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+}
@@ -0,0 +1,170 @@
+package batches
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/pingencom/pingen2-sdk-go/response"
+)
+
+// ChangeWindowPosition moves the address window of every letter in the batch.
+func (b *Batches) ChangeWindowPosition(batchID string, addressPosition AddressPosition) (BatchResponse, *errors.PingenError) {
+	if err := b.checkAbility(batchID, "change-window-position", func(batch BatchResponse) string {
+		return batch.Data.Meta.Abilities.Self.ChangeWindowPosition
+	}); err != nil {
+		return BatchResponse{}, err
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   batchID,
+			"type": "batches",
+			"attributes": map[string]interface{}{
+				"address_position": string(addressPosition),
+			},
+		},
+	})
+	url := fmt.Sprintf("/organisations/%s/batches/%s/change-window-position", b.organisationID, batchID)
+
+	var response BatchResponse
+
+	_, err := b.apiRequestor.PerformPatchRequest(url, &response, payload, nil)
+	if err != nil {
+		return BatchResponse{}, err
+	}
+
+	return response, nil
+}
+
+// BatchUpdate groups the changes UpdateBatch applies to a whole batch. Zero fields are left unchanged.
+type BatchUpdate struct {
+	AddressPosition AddressPosition
+	PaperTypes      []string
+	// Attachments are paths of PDFs uploaded and attached to every letter of the batch.
+	Attachments []string
+}
+
+// UpdateBatch applies the window position, paper types and attachments in that order and
+// stops at the first error. The returned batch reflects the last change made.
+func (b *Batches) UpdateBatch(batchID string, update BatchUpdate) (BatchResponse, *errors.PingenError) {
+	var response BatchResponse
+	var err *errors.PingenError
+
+	if update.AddressPosition != "" {
+		if response, err = b.ChangeWindowPosition(batchID, update.AddressPosition); err != nil {
+			return BatchResponse{}, err
+		}
+	}
+
+	if update.PaperTypes != nil {
+		if err := b.checkAbility(batchID, "edit", func(batch BatchResponse) string {
+			return batch.Data.Meta.Abilities.Self.Edit
+		}); err != nil {
+			return BatchResponse{}, err
+		}
+
+		if response, err = b.EditBatch(batchID, update.PaperTypes); err != nil {
+			return BatchResponse{}, err
+		}
+	}
+
+	if len(update.Attachments) > 0 {
+		if err := b.checkAbility(batchID, "add-attachment", func(batch BatchResponse) string {
+			return batch.Data.Meta.Abilities.Self.AddAttachment
+		}); err != nil {
+			return BatchResponse{}, err
+		}
+
+		for _, path := range update.Attachments {
+			if _, err := b.UploadAndAddAttachment(batchID, path, filepath.Base(path)); err != nil {
+				return BatchResponse{}, err
+			}
+		}
+
+		if response, err = b.GetDetails(batchID, nil, nil); err != nil {
+			return BatchResponse{}, err
+		}
+	}
+
+	return response, nil
+}
+
+// LetterOverride changes a single letter of a batch. Zero fields are left unchanged.
+type LetterOverride struct {
+	LetterID        string
+	AddressPosition letters.AddressPosition
+	PaperTypes      []string
+}
+
+type OverrideResult struct {
+	LetterID string
+	Letter   letters.LetterResponse
+	Err      *errors.PingenError
+}
+
+// ApplyLetterOverrides applies each override to its letter after checking that the letter
+// belongs to the batch. A failing override does not stop the others; results are in input order.
+func (b *Batches) ApplyLetterOverrides(batchID string, overrides []LetterOverride) []OverrideResult {
+	results := make([]OverrideResult, 0, len(overrides))
+
+	for _, override := range overrides {
+		letter, err := b.applyLetterOverride(batchID, override)
+		results = append(results, OverrideResult{LetterID: override.LetterID, Letter: letter, Err: err})
+	}
+
+	return results
+}
+
+func (b *Batches) applyLetterOverride(batchID string, override LetterOverride) (letters.LetterResponse, *errors.PingenError) {
+	letter, err := b.GetLetter(batchID, override.LetterID)
+	if err != nil {
+		return letters.LetterResponse{}, err
+	}
+
+	letterClient := b.letterClient()
+
+	if override.AddressPosition != "" {
+		input := letters.ChangeWindowPositionInput{AddressPosition: override.AddressPosition}
+		if letter, err = letterClient.ChangeWindowPosition(override.LetterID, input); err != nil {
+			return letters.LetterResponse{}, err
+		}
+	}
+
+	if override.PaperTypes != nil {
+		if letter, err = letterClient.Edit(override.LetterID, override.PaperTypes); err != nil {
+			return letters.LetterResponse{}, err
+		}
+	}
+
+	return letter, nil
+}
+
+// checkAbility fetches the batch and fails with a 409 unless the ability is granted.
+func (b *Batches) checkAbility(batchID, ability string, value func(BatchResponse) string) *errors.PingenError {
+	batch, err := b.GetDetails(batchID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if granted := value(batch); granted == "" || granted == response.AbilityOK {
+		return nil
+	}
+
+	status := batch.Data.Attributes.Status
+	body, _ := json.Marshal(map[string]string{
+		"batch_id": batchID,
+		"status":   status,
+		"action":   ability,
+	})
+
+	return errors.NewPingenError(
+		fmt.Sprintf("Batch cannot %s in status %q", ability, status),
+		string(body),
+		http.StatusConflict,
+		nil,
+	)
+}
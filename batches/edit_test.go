@@ -0,0 +1,122 @@
+package batches_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/batches"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+func batchWithAbilities(status, changeWindowPosition, edit string) string {
+	return fmt.Sprintf(
+		`{"data": {"id": "test-batch-id", "type": "batches", "attributes": {"status": "%s"}, "meta": {"abilities": {"self": {"change-window-position": "%s", "edit": "%s"}}}}}`,
+		status, changeWindowPosition, edit,
+	)
+}
+
+func TestChangeWindowPosition(t *testing.T) {
+	details := batchWithAbilities("action_required", "ok", "ok")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id":
+			_, _ = w.Write([]byte(details))
+		case "PATCH /organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/change-window-position":
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"data": {"id": "test-batch-id", "type": "batches", "attributes": {"address_position": "right"}}}`, string(body))
+			_, _ = w.Write([]byte(mockBatchResponse))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	batchClient := setupBatch(server.URL)
+
+	resp, err := batchClient.ChangeWindowPosition("test-batch-id", batches.AddressPositionRight)
+	assert.Nil(t, err)
+	assert.Equal(t, "test-batch-id", resp.Data.ID)
+
+	details = batchWithAbilities("sent", "state", "state")
+	_, err = batchClient.ChangeWindowPosition("test-batch-id", batches.AddressPositionRight)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, `Batch cannot change-window-position in status "sent"`, err.Message)
+}
+
+func TestUpdateBatch(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(batchWithAbilities("valid", "ok", "ok")))
+		default:
+			_, _ = w.Write([]byte(mockBatchResponse))
+		}
+	}))
+	defer server.Close()
+
+	_, err := setupBatch(server.URL).UpdateBatch("test-batch-id", batches.BatchUpdate{
+		AddressPosition: batches.AddressPositionLeft,
+		PaperTypes:      []string{"normal", "qr"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"GET /organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id",
+		"PATCH /organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/change-window-position",
+		"GET /organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id",
+		"PATCH /organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id",
+	}, requests)
+
+	requests = nil
+	_, err = setupBatch(server.URL).UpdateBatch("test-batch-id", batches.BatchUpdate{})
+	assert.Nil(t, err)
+	assert.Empty(t, requests)
+}
+
+func TestApplyLetterOverrides(t *testing.T) {
+	var patches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/"
+
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			patches = append(patches, r.URL.Path[len(prefix):]+" "+string(body))
+		}
+
+		switch r.URL.Path {
+		case prefix + "letter-1", prefix + "letter-1/change-window-position":
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, letterItem("letter-1", "action_required", "test-batch-id"))
+		case prefix + "letter-2":
+			_, _ = fmt.Fprintf(w, `{"data": %s}`, letterItem("letter-2", "action_required", "other-batch"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	results := setupBatch(server.URL).ApplyLetterOverrides("test-batch-id", []batches.LetterOverride{
+		{LetterID: "letter-2", AddressPosition: letters.AddressPositionRight},
+		{LetterID: "letter-1", AddressPosition: letters.AddressPositionRight, PaperTypes: []string{"qr"}},
+	})
+
+	assert.Len(t, results, 2)
+	assert.Equal(t, "letter-2", results[0].LetterID)
+	assert.Equal(t, http.StatusNotFound, results[0].Err.StatusCode)
+	assert.Equal(t, "letter-1", results[1].LetterID)
+	assert.Nil(t, results[1].Err)
+	assert.Equal(t, "letter-1", results[1].Letter.Data.ID)
+
+	assert.Len(t, patches, 2)
+	assert.Contains(t, patches[0], `change-window-position {`)
+	assert.Contains(t, patches[0], `"address_position":"right"`)
+	assert.Contains(t, patches[1], `letter-1 {`)
+	assert.Contains(t, patches[1], `"paper_types":["qr"]`)
+}
@@ -0,0 +1,143 @@
+package batches
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+// Split says how Pingen turns the uploaded file into letters. Each implementation
+// carries exactly the options its split type accepts and implies the grouping type,
+// so only valid combinations can be expressed.
+type Split interface {
+	GroupingType() GroupingType
+	SplitType() SplitType
+	Validate() *errors.PingenError
+	attributes() map[string]interface{}
+}
+
+// SplitByFile makes a letter of every PDF in an uploaded ZIP.
+type SplitByFile struct{}
+
+// SplitByPage merges the upload and starts a new letter every Size pages.
+type SplitByPage struct {
+	Size int
+}
+
+// SplitByCustom merges the upload and starts a new letter at every page containing Separator,
+// which is either the first or the last page of a letter depending on Position.
+type SplitByCustom struct {
+	Separator string
+	Position  SplitPosition
+}
+
+// SplitByQRInvoice merges the upload and ends a letter at every page with a QR invoice.
+type SplitByQRInvoice struct{}
+
+func (SplitByFile) GroupingType() GroupingType      { return GroupingTypeZip }
+func (SplitByPage) GroupingType() GroupingType      { return GroupingTypeMerge }
+func (SplitByCustom) GroupingType() GroupingType    { return GroupingTypeMerge }
+func (SplitByQRInvoice) GroupingType() GroupingType { return GroupingTypeMerge }
+
+func (SplitByFile) SplitType() SplitType      { return SplitTypeFile }
+func (SplitByPage) SplitType() SplitType      { return SplitTypePage }
+func (SplitByCustom) SplitType() SplitType    { return SplitTypeCustom }
+func (SplitByQRInvoice) SplitType() SplitType { return SplitTypeQRInvoice }
+
+func (SplitByFile) Validate() *errors.PingenError { return nil }
+
+func (s SplitByPage) Validate() *errors.PingenError {
+	if s.Size < 1 {
+		return newGroupingError("grouping_options_split_size", "must be at least 1")
+	}
+	return nil
+}
+
+func (s SplitByCustom) Validate() *errors.PingenError {
+	if s.Separator == "" {
+		return newGroupingError("grouping_options_split_separator", "is required")
+	}
+
+	if s.Position != SplitPositionFirstPage && s.Position != SplitPositionLastPage {
+		return newGroupingError("grouping_options_split_position", fmt.Sprintf("must be %q or %q", SplitPositionFirstPage, SplitPositionLastPage))
+	}
+
+	return nil
+}
+
+func (SplitByQRInvoice) Validate() *errors.PingenError { return nil }
+
+func (SplitByFile) attributes() map[string]interface{} { return nil }
+
+func (s SplitByPage) attributes() map[string]interface{} {
+	return map[string]interface{}{"grouping_options_split_size": s.Size}
+}
+
+func (s SplitByCustom) attributes() map[string]interface{} {
+	return map[string]interface{}{
+		"grouping_options_split_separator": s.Separator,
+		"grouping_options_split_position":  string(s.Position),
+	}
+}
+
+func (SplitByQRInvoice) attributes() map[string]interface{} { return nil }
+
+// UploadAndCreateBatchWithSplit is UploadAndCreateBatch with typed grouping options.
+func (b *Batches) UploadAndCreateBatchWithSplit(
+	pathToFile, name string, icon Icon, fileOriginalName string, addressPosition AddressPosition, split Split,
+) (BatchResponse, *errors.PingenError) {
+	if err := validateSplit(split); err != nil {
+		return BatchResponse{}, err
+	}
+
+	return b.upload(pathToFile, func(fileURL, fileURLSignature string) (BatchResponse, *errors.PingenError) {
+		return b.CreateBatchWithSplit(fileURL, fileURLSignature, name, icon, fileOriginalName, addressPosition, split)
+	})
+}
+
+// CreateBatchWithSplit is CreateBatch with typed grouping options, which are validated before the request is made.
+func (b *Batches) CreateBatchWithSplit(
+	fileURL, fileURLSignature, name string, icon Icon, fileOriginalName string, addressPosition AddressPosition, split Split,
+) (BatchResponse, *errors.PingenError) {
+	if err := validateSplit(split); err != nil {
+		return BatchResponse{}, err
+	}
+
+	attributes := map[string]interface{}{
+		"file_url":                    fileURL,
+		"file_url_signature":          fileURLSignature,
+		"name":                        name,
+		"icon":                        string(icon),
+		"file_original_name":          fileOriginalName,
+		"address_position":            string(addressPosition),
+		"grouping_type":               string(split.GroupingType()),
+		"grouping_options_split_type": string(split.SplitType()),
+	}
+
+	for key, value := range split.attributes() {
+		attributes[key] = value
+	}
+
+	return b.createBatch(attributes)
+}
+
+func validateSplit(split Split) *errors.PingenError {
+	if split == nil {
+		return newGroupingError("grouping_options_split_type", "is required")
+	}
+
+	return split.Validate()
+}
+
+func newGroupingError(field, problem string) *errors.PingenError {
+	body, _ := json.Marshal(map[string]string{field: problem})
+
+	return errors.NewPingenError(
+		fmt.Sprintf("Invalid grouping options: %s %s", field, problem),
+		string(body),
+		http.StatusUnprocessableEntity,
+		nil,
+	)
+}
@@ -0,0 +1,151 @@
+package batches_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/batches"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitValidate(t *testing.T) {
+	assert.Nil(t, batches.SplitByFile{}.Validate())
+	assert.Nil(t, batches.SplitByPage{Size: 1}.Validate())
+	assert.Nil(t, batches.SplitByCustom{Separator: "%%SPLIT%%", Position: batches.SplitPositionLastPage}.Validate())
+	assert.Nil(t, batches.SplitByQRInvoice{}.Validate())
+
+	err := batches.SplitByPage{}.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, "Invalid grouping options: grouping_options_split_size must be at least 1", err.Message)
+
+	err = batches.SplitByCustom{Position: batches.SplitPositionFirstPage}.Validate()
+	assert.Equal(t, map[string]interface{}{"grouping_options_split_separator": "is required"}, err.JSONBody)
+
+	err = batches.SplitByCustom{Separator: "%%SPLIT%%", Position: "middle"}.Validate()
+	assert.Equal(t, `Invalid grouping options: grouping_options_split_position must be "first_page" or "last_page"`, err.Message)
+}
+
+func TestCreateBatchWithSplit(t *testing.T) {
+	tests := []struct {
+		split    batches.Split
+		expected map[string]interface{}
+	}{
+		{
+			split: batches.SplitByFile{},
+			expected: map[string]interface{}{
+				"grouping_type":               "zip",
+				"grouping_options_split_type": "file",
+			},
+		},
+		{
+			split: batches.SplitByPage{Size: 3},
+			expected: map[string]interface{}{
+				"grouping_type":               "merge",
+				"grouping_options_split_type": "page",
+				"grouping_options_split_size": float64(3),
+			},
+		},
+		{
+			split: batches.SplitByCustom{Separator: "%%SPLIT%%", Position: batches.SplitPositionFirstPage},
+			expected: map[string]interface{}{
+				"grouping_type":                    "merge",
+				"grouping_options_split_type":      "custom",
+				"grouping_options_split_separator": "%%SPLIT%%",
+				"grouping_options_split_position":  "first_page",
+			},
+		},
+		{
+			split: batches.SplitByQRInvoice{},
+			expected: map[string]interface{}{
+				"grouping_type":               "merge",
+				"grouping_options_split_type": "qr_invoice",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.split.SplitType()), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches", r.URL.Path)
+
+				var payload struct {
+					Data struct {
+						Attributes map[string]interface{} `json:"attributes"`
+					} `json:"data"`
+				}
+				body, _ := io.ReadAll(r.Body)
+				assert.Nil(t, json.Unmarshal(body, &payload))
+
+				grouping := map[string]interface{}{}
+				for key, value := range payload.Data.Attributes {
+					if strings.HasPrefix(key, "grouping_") {
+						grouping[key] = value
+					}
+				}
+				assert.Equal(t, tt.expected, grouping)
+				assert.Equal(t, "Invoices", payload.Data.Attributes["name"])
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(mockBatchResponse))
+			}))
+			defer server.Close()
+
+			resp, err := setupBatch(server.URL).CreateBatchWithSplit(
+				"https://upload.example.com/file",
+				"signature",
+				"Invoices",
+				batches.IconReceipt,
+				"invoices.pdf",
+				batches.AddressPositionLeft,
+				tt.split,
+			)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "test-batch-id", resp.Data.ID)
+		})
+	}
+}
+
+func TestUploadAndCreateBatchWithSplit(t *testing.T) {
+	requests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		switch r.URL.Path {
+		case "/file-upload":
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `{"data": {"attributes": {"url": "%s/upload", "url_signature": "signature"}}}`, server.URL)
+		case "/upload":
+			w.WriteHeader(http.StatusOK)
+		default:
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"grouping_options_split_type":"qr_invoice"`)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(mockBatchResponse))
+		}
+	}))
+	defer server.Close()
+
+	batchClient := setupBatch(server.URL)
+
+	_, err := batchClient.UploadAndCreateBatchWithSplit("test.zip", "Invoices", batches.IconReceipt, "test.zip", batches.AddressPositionLeft, batches.SplitByPage{})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, 0, requests)
+
+	_, err = batchClient.UploadAndCreateBatchWithSplit("test.zip", "Invoices", batches.IconReceipt, "test.zip", batches.AddressPositionLeft, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, requests)
+
+	resp, err := batchClient.UploadAndCreateBatchWithSplit("test.zip", "Invoices", batches.IconReceipt, "test.zip", batches.AddressPositionLeft, batches.SplitByQRInvoice{})
+	assert.Nil(t, err)
+	assert.Equal(t, "test-batch-id", resp.Data.ID)
+	assert.Equal(t, 3, requests)
+}
@@ -0,0 +1,204 @@
+package batches
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/batchevents"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+type UpdateKind string
+
+const (
+	UpdateProgress  UpdateKind = "progress"
+	UpdateEvent     UpdateKind = "event"
+	UpdateStalled   UpdateKind = "stalled"
+	UpdateError     UpdateKind = "error"
+	UpdateCompleted UpdateKind = "completed"
+)
+
+// terminalStatuses are the batch statuses after which the batch no longer changes.
+var terminalStatuses = map[string]bool{
+	"sent":      true,
+	"cancelled": true,
+	"invalid":   true,
+	"expired":   true,
+}
+
+// Progress holds the batch's own status and the letter counts from the batch statistics.
+type Progress struct {
+	Status    string
+	Total     int
+	Processed int
+	Sent      int
+	Cancelled int
+	Errors    int
+}
+
+// Done reports whether the batch reached a terminal status or every letter of the batch
+// has been sent, cancelled or failed. The status also covers batches without letters.
+func (p Progress) Done() bool {
+	return terminalStatuses[p.Status] || (p.Total > 0 && p.Sent+p.Cancelled+p.Errors >= p.Total)
+}
+
+// Update is sent by Watch. Progress is set on every kind, Event only for UpdateEvent
+// and Err only for UpdateError.
+type Update struct {
+	Kind     UpdateKind
+	Progress Progress
+	Event    batchevents.BatchEvent
+	Err      *errors.PingenError
+}
+
+type WatchOptions struct {
+	// PollInterval is the time between polls. Defaults to 5 seconds.
+	PollInterval time.Duration
+	// StallTimeout reports the batch as stalled when neither the statistics nor the events
+	// change for this long. Zero disables stall detection.
+	StallTimeout time.Duration
+}
+
+// Watch polls the batch, its statistics and its events until the batch is done, a
+// request is rejected or ctx is done. Progress is reported whenever the
+// statistics change and each event is reported once. Transient errors are reported
+// and polling continues. The channel is closed when watching ends and must be drained.
+func (b *Batches) Watch(ctx context.Context, batchID string, options WatchOptions) <-chan Update {
+	if options.PollInterval <= 0 {
+		options.PollInterval = 5 * time.Second
+	}
+
+	updates := make(chan Update)
+
+	go func() {
+		defer close(updates)
+		b.watch(ctx, batchID, options, updates)
+	}()
+
+	return updates
+}
+
+func (b *Batches) watch(ctx context.Context, batchID string, options WatchOptions, updates chan<- Update) {
+	eventClient := batchevents.NewBatchEvents(b.organisationID, b.apiRequestor)
+	seen := map[string]bool{}
+	eventPage := 1
+
+	var progress Progress
+	first := true
+	stalled := false
+	lastChange := time.Now()
+
+	emit := func(update Update) bool {
+		update.Progress = progress
+		select {
+		case updates <- update:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		changed := false
+
+		current, err := b.progress(batchID)
+		if err != nil {
+			if !emit(Update{Kind: UpdateError, Err: err}) || errors.IsRejection(err) {
+				return
+			}
+		} else if first || current != progress {
+			first = false
+			progress = current
+			changed = true
+			if !emit(Update{Kind: UpdateProgress}) {
+				return
+			}
+		}
+
+		events, err := newEvents(eventClient, batchID, &eventPage, seen)
+		if err != nil {
+			if !emit(Update{Kind: UpdateError, Err: err}) || errors.IsRejection(err) {
+				return
+			}
+		}
+		for _, event := range events {
+			changed = true
+			if !emit(Update{Kind: UpdateEvent, Event: event}) {
+				return
+			}
+		}
+
+		if progress.Done() {
+			emit(Update{Kind: UpdateCompleted})
+			return
+		}
+
+		if changed {
+			lastChange = time.Now()
+			stalled = false
+		} else if options.StallTimeout > 0 && !stalled && time.Since(lastChange) >= options.StallTimeout {
+			stalled = true
+			if !emit(Update{Kind: UpdateStalled}) {
+				return
+			}
+		}
+
+		select {
+		case <-time.After(options.PollInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *Batches) progress(batchID string) (Progress, *errors.PingenError) {
+	batch, err := b.GetDetails(batchID, nil, nil)
+	if err != nil {
+		return Progress{}, err
+	}
+
+	statistics, err := b.GetStatistics(batchID)
+	if err != nil {
+		return Progress{}, err
+	}
+
+	attributes := statistics.Data.Attributes
+
+	return Progress{
+		Status:    batch.Data.Attributes.Status,
+		Total:     attributes.TotalLetters,
+		Processed: attributes.ProcessedLetters,
+		Sent:      attributes.SentLetters,
+		Cancelled: attributes.CancelledLetters,
+		Errors:    attributes.ErrorLetters,
+	}, nil
+}
+
+// newEvents returns the batch events not yet in seen and adds them to seen. Events are
+// requested oldest first, so new ones only appear on the last page: paging starts at *page,
+// the last page seen before, and *page is moved to the last page now.
+func newEvents(eventClient *batchevents.BatchEvents, batchID string, page *int, seen map[string]bool) ([]batchevents.BatchEvent, *errors.PingenError) {
+	var events []batchevents.BatchEvent
+
+	for ; ; *page++ {
+		response, err := eventClient.GetCollection(batchID, map[string]string{
+			"page[number]": strconv.Itoa(*page),
+			"sort":         "created_at",
+		}, nil)
+		if err != nil {
+			return events, err
+		}
+
+		for _, event := range response.Data {
+			if !seen[event.ID] {
+				seen[event.ID] = true
+				events = append(events, event)
+			}
+		}
+
+		if *page >= response.Meta.LastPage {
+			return events, nil
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package batches_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/batches"
+	"github.com/stretchr/testify/assert"
+)
+
+func statisticsResponse(total, processed, sent, cancelled, failed int) string {
+	return fmt.Sprintf(
+		`{"data": {"id": "test-batch-id", "type": "batch_statistics", "attributes": {"total_letters": %d, "processed_letters": %d, "sent_letters": %d, "cancelled_letters": %d, "error_letters": %d}}}`,
+		total, processed, sent, cancelled, failed,
+	)
+}
+
+func batchStatusResponse(status string) string {
+	return fmt.Sprintf(`{"data": {"id": "test-batch-id", "type": "batches", "attributes": {"status": "%s"}}}`, status)
+}
+
+func eventsResponse(codes ...string) string {
+	data := ""
+	for i, code := range codes {
+		if i > 0 {
+			data += ","
+		}
+		data += fmt.Sprintf(`{"id": "event-%s", "type": "batches_events", "attributes": {"code": "%s"}}`, code, code)
+	}
+
+	return fmt.Sprintf(`{"data": [%s], "meta": {"current_page": 1, "last_page": 1}}`, data)
+}
+
+func TestWatch(t *testing.T) {
+	statistics := []string{
+		statisticsResponse(3, 0, 0, 0, 0),
+		statisticsResponse(3, 0, 0, 0, 0),
+		statisticsResponse(3, 3, 1, 0, 0),
+		statisticsResponse(3, 3, 2, 0, 1),
+	}
+	events := []string{
+		eventsResponse(),
+		eventsResponse("processing"),
+		eventsResponse("processing"),
+		eventsResponse("processing", "submitted"),
+	}
+
+	var mu sync.Mutex
+	polls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id":
+			_, _ = w.Write([]byte(batchStatusResponse("submitted")))
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/statistics":
+			_, _ = w.Write([]byte(statistics[polls["statistics"]]))
+			polls["statistics"]++
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/events":
+			_, _ = w.Write([]byte(events[polls["events"]]))
+			polls["events"]++
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var kinds []batches.UpdateKind
+	var last batches.Update
+	for update := range setupBatch(server.URL).Watch(context.Background(), "test-batch-id", batches.WatchOptions{PollInterval: time.Millisecond}) {
+		kinds = append(kinds, update.Kind)
+		if update.Kind == batches.UpdateEvent {
+			assert.Contains(t, []string{"processing", "submitted"}, update.Event.Attributes.Code)
+		}
+		last = update
+	}
+
+	assert.Equal(t, []batches.UpdateKind{
+		batches.UpdateProgress,
+		batches.UpdateEvent,
+		batches.UpdateProgress,
+		batches.UpdateProgress,
+		batches.UpdateEvent,
+		batches.UpdateCompleted,
+	}, kinds)
+	assert.Equal(t, batches.Progress{Status: "submitted", Total: 3, Processed: 3, Sent: 2, Errors: 1}, last.Progress)
+	assert.True(t, last.Progress.Done())
+}
+
+func TestWatch_Stalled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id":
+			_, _ = w.Write([]byte(batchStatusResponse("submitted")))
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/statistics":
+			_, _ = w.Write([]byte(statisticsResponse(3, 1, 0, 0, 0)))
+		default:
+			_, _ = w.Write([]byte(eventsResponse()))
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates := setupBatch(server.URL).Watch(ctx, "test-batch-id", batches.WatchOptions{
+		PollInterval: time.Millisecond,
+		StallTimeout: 20 * time.Millisecond,
+	})
+
+	assert.Equal(t, batches.UpdateProgress, (<-updates).Kind)
+
+	stalled := <-updates
+	assert.Equal(t, batches.UpdateStalled, stalled.Kind)
+	assert.Equal(t, 1, stalled.Progress.Processed)
+
+	cancel()
+	for range updates {
+		t.Error("no updates expected after cancelling")
+	}
+}
+
+func TestWatch_Errors(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id":
+			_, _ = w.Write([]byte(batchStatusResponse("submitted")))
+			return
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/events":
+			_, _ = w.Write([]byte(eventsResponse()))
+			return
+		}
+
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors": [{"title": "Not found"}]}`))
+	}))
+	defer server.Close()
+
+	var statuses []int
+	for update := range setupBatch(server.URL).Watch(context.Background(), "test-batch-id", batches.WatchOptions{PollInterval: time.Millisecond}) {
+		assert.Equal(t, batches.UpdateError, update.Kind)
+		statuses = append(statuses, update.Err.StatusCode)
+	}
+
+	assert.Equal(t, []int{http.StatusServiceUnavailable, http.StatusNotFound}, statuses)
+}
+
+func TestWatch_TerminalStatusWithoutLetters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id":
+			_, _ = w.Write([]byte(batchStatusResponse("cancelled")))
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/statistics":
+			_, _ = w.Write([]byte(statisticsResponse(0, 0, 0, 0, 0)))
+		default:
+			_, _ = w.Write([]byte(eventsResponse()))
+		}
+	}))
+	defer server.Close()
+
+	var kinds []batches.UpdateKind
+	var last batches.Update
+	for update := range setupBatch(server.URL).Watch(context.Background(), "test-batch-id", batches.WatchOptions{PollInterval: time.Millisecond}) {
+		kinds = append(kinds, update.Kind)
+		last = update
+	}
+
+	assert.Equal(t, []batches.UpdateKind{batches.UpdateProgress, batches.UpdateCompleted}, kinds)
+	assert.Equal(t, "cancelled", last.Progress.Status)
+}
+
+func TestWatch_EventsPageFromLastPageSeen(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.URL.Path {
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id":
+			_, _ = w.Write([]byte(batchStatusResponse("submitted")))
+		case "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/batches/test-batch-id/statistics":
+			_, _ = w.Write([]byte(statisticsResponse(3, 1, 0, 0, 0)))
+		default:
+			page := r.URL.Query().Get("page[number]")
+			assert.Equal(t, "created_at", r.URL.Query().Get("sort"))
+			pages = append(pages, page)
+			if len(pages) == 5 {
+				cancel()
+			}
+			_, _ = fmt.Fprintf(w, `{"data": [{"id": "event-%s", "type": "batches_events", "attributes": {"code": "processing"}}], "meta": {"current_page": %s, "last_page": 3}}`, page, page)
+		}
+	}))
+	defer server.Close()
+
+	events := 0
+	for update := range setupBatch(server.URL).Watch(ctx, "test-batch-id", batches.WatchOptions{PollInterval: time.Millisecond}) {
+		if update.Kind == batches.UpdateEvent {
+			events++
+		}
+	}
+
+	assert.Equal(t, 3, events)
+	mu.Lock()
+	assert.Equal(t, []string{"1", "2", "3", "3", "3"}, pages[:5])
+	mu.Unlock()
+}
@@ -12,36 +12,38 @@ type BatchEvents struct {
 	apiRequestor   *api.APIRequestor
 }
 
+type BatchEvent struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Attributes struct {
+		Code      string   `json:"code"`
+		Name      string   `json:"name"`
+		Producer  string   `json:"producer"`
+		Location  string   `json:"location"`
+		Data      []string `json:"data"`
+		EmittedAt string   `json:"emitted_at"`
+		CreatedAt string   `json:"created_at"`
+		UpdatedAt string   `json:"updated_at"`
+	} `json:"attributes"`
+	Relationships struct {
+		Batch struct {
+			Links struct {
+				Related string `json:"related"`
+			} `json:"links"`
+			Data struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			} `json:"data"`
+		} `json:"letter"`
+	} `json:"relationships"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"links"`
+}
+
 type BatchEventsCollectionResponse struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			Code      string   `json:"code"`
-			Name      string   `json:"name"`
-			Producer  string   `json:"producer"`
-			Location  string   `json:"location"`
-			Data      []string `json:"data"`
-			EmittedAt string   `json:"emitted_at"`
-			CreatedAt string   `json:"created_at"`
-			UpdatedAt string   `json:"updated_at"`
-		} `json:"attributes"`
-		Relationships struct {
-			Batch struct {
-				Links struct {
-					Related string `json:"related"`
-				} `json:"links"`
-				Data struct {
-					ID   string `json:"id"`
-					Type string `json:"type"`
-				} `json:"data"`
-			} `json:"letter"`
-		} `json:"relationships"`
-		Links struct {
-			Self string `json:"self"`
-		} `json:"links"`
-	} `json:"data"`
-	Included []struct{} `json:"included"`
+	Data     []BatchEvent `json:"data"`
+	Included []struct{}   `json:"included"`
 	Links    struct {
 		First string `json:"first"`
 		Last  string `json:"last"`
@@ -0,0 +1,359 @@
+package budget
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/batches"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/pingencom/pingen2-sdk-go/organisations"
+	"github.com/pingencom/pingen2-sdk-go/pricing"
+)
+
+const (
+	LimitPerSend  = "per_send"
+	LimitPerDay   = "per_day"
+	LimitPerMonth = "per_month"
+	LimitBalance  = "balance"
+)
+
+// Limits are expressed in Currency. A zero limit is not enforced.
+type Limits struct {
+	Currency string
+	PerSend  float64
+	PerDay   float64
+	PerMonth float64
+	// EnforceBalance blocks sends that cost more than the organisation's billing balance.
+	EnforceBalance bool
+}
+
+type ApprovalRequest struct {
+	Kind       string
+	ResourceID string
+	Exceeded   *errors.BudgetExceededError
+}
+
+// Approver is asked whether a send that would exceed a limit may go ahead anyway.
+type Approver func(request ApprovalRequest) bool
+
+type Options struct {
+	Limits Limits
+	// Approve is optional; without it every send exceeding a limit is blocked.
+	Approve Approver
+	// Location decides where days and months begin. Defaults to UTC.
+	Location *time.Location
+}
+
+// Guard sends letters and batches only when their cost stays within the configured limits.
+// Spending is tracked in memory per Guard, so all sends of a process should share one;
+// use AddSpending to carry over what was spent before a restart.
+type Guard struct {
+	organisationID string
+	letterClient   *letters.Letters
+	batchClient    *batches.Batches
+	organisations  *organisations.Organisations
+	options        Options
+
+	mu       sync.Mutex
+	day      string
+	month    string
+	dayTotal float64
+	monTotal float64
+}
+
+func NewGuard(organisationID string, apiRequestor *api.APIRequestor, options Options) *Guard {
+	if options.Location == nil {
+		options.Location = time.UTC
+	}
+
+	return &Guard{
+		organisationID: organisationID,
+		letterClient:   letters.NewLetters(organisationID, apiRequestor),
+		batchClient:    batches.NewBatches(organisationID, apiRequestor),
+		organisations:  organisations.NewOrganisations(apiRequestor),
+		options:        options,
+	}
+}
+
+// SendLetter prices the letter with the given delivery settings and sends it if the cost fits the budget.
+// A blocked send returns a *errors.BudgetExceededError, any other failure a *errors.PingenError.
+func (g *Guard) SendLetter(
+	letterID, deliveryProduct, printMode, printSpectrum string,
+) (letters.LetterResponse, error) {
+	letter, err := g.letterClient.GetDetails(letterID, nil, nil)
+	if err != nil {
+		return letters.LetterResponse{}, err
+	}
+
+	price, err := g.letterClient.CalculatePrice(
+		letter.Data.Attributes.Country,
+		letter.Data.Attributes.PaperTypes,
+		printMode,
+		printSpectrum,
+		deliveryProduct,
+	)
+	if err != nil {
+		return letters.LetterResponse{}, err
+	}
+
+	cost, currency := price.Data.Attributes.Price, price.Data.Attributes.Currency
+
+	reserved, reserveErr := g.reserve("letter", letterID, cost, currency)
+	if reserveErr != nil {
+		return letters.LetterResponse{}, reserveErr
+	}
+
+	response, err := g.letterClient.Send(letterID, deliveryProduct, printMode, printSpectrum)
+	if err != nil {
+		g.release(reserved)
+		return letters.LetterResponse{}, err
+	}
+
+	return response, nil
+}
+
+// SendBatch prices every letter of the batch with the given delivery settings, like SendLetter
+// does, and sends the batch if the total fits the budget. deliveryProducts maps country codes
+// to products. Errors are returned as by SendLetter.
+func (g *Guard) SendBatch(
+	batchID string,
+	deliveryProducts map[string]string,
+	printMode, printSpectrum string,
+) (batches.BatchResponse, error) {
+	cost, currency, err := g.priceBatch(batchID, deliveryProducts, printMode, printSpectrum)
+	if err != nil {
+		return batches.BatchResponse{}, err
+	}
+
+	reserved, reserveErr := g.reserve("batch", batchID, cost, currency)
+	if reserveErr != nil {
+		return batches.BatchResponse{}, reserveErr
+	}
+
+	response, err := g.batchClient.SendBatch(batchID, deliveryProducts, printMode, printSpectrum)
+	if err != nil {
+		g.release(reserved)
+		return batches.BatchResponse{}, err
+	}
+
+	return response, nil
+}
+
+// Check reports the first limit that a send costing cost would exceed, without recording anything.
+func (g *Guard) Check(cost float64, currency string) *errors.BudgetExceededError {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.check(cost, currency, time.Now())
+}
+
+// Spent returns what was sent today and this month through the guard.
+func (g *Guard) Spent() (day, month float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.roll(time.Now())
+	return g.dayTotal, g.monTotal
+}
+
+// AddSpending counts amount towards today's and this month's totals.
+func (g *Guard) AddSpending(amount float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.roll(time.Now())
+	g.dayTotal += amount
+	g.monTotal += amount
+}
+
+// priceBatch sums the prices of the batch's letters with the given delivery settings.
+// A batch that cannot be priced, or whose price comes to nothing, is refused.
+func (g *Guard) priceBatch(
+	batchID string,
+	deliveryProducts map[string]string,
+	printMode, printSpectrum string,
+) (float64, string, *errors.PingenError) {
+	var inputs []pricing.Input
+
+	iterator := g.batchClient.Letters(batchID, batches.LetterFilter{})
+	for iterator.Next() {
+		attributes := iterator.Letter().Attributes
+
+		product, ok := productFor(deliveryProducts, attributes.Country)
+		if !ok {
+			return 0, "", newPriceError(fmt.Sprintf("No delivery product for %s letters in batch %q", attributes.Country, batchID))
+		}
+
+		inputs = append(inputs, pricing.Input{
+			Country:         attributes.Country,
+			PaperTypes:      attributes.PaperTypes,
+			PrintMode:       printMode,
+			PrintSpectrum:   printSpectrum,
+			DeliveryProduct: product,
+		})
+	}
+
+	if err := iterator.Err(); err != nil {
+		return 0, "", err
+	}
+
+	if len(inputs) == 0 {
+		return 0, "", newPriceError(fmt.Sprintf("Cannot price batch %q without letters", batchID))
+	}
+
+	// A fresh quoter looks each distinct combination up once, without keeping prices around.
+	estimate, err := pricing.NewQuoter(g.letterClient, pricing.Options{}).Estimate(inputs)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if len(estimate.Totals) != 1 {
+		return 0, "", newPriceError(fmt.Sprintf("Batch %q is priced in %d currencies", batchID, len(estimate.Totals)))
+	}
+
+	for currency, total := range estimate.Totals {
+		return total, currency, nil
+	}
+
+	return 0, "", nil
+}
+
+// reservation is a cost counted towards the totals of the day and month it was made in.
+type reservation struct {
+	cost  float64
+	day   string
+	month string
+}
+
+// reserve counts the cost before the send is made, so concurrent sends cannot
+// overrun a limit together. Failed sends give their reservation back.
+func (g *Guard) reserve(kind, resourceID string, cost float64, currency string) (reservation, error) {
+	limits := g.options.Limits
+
+	if cost <= 0 || currency == "" {
+		return reservation{}, newPriceError(fmt.Sprintf("Cannot apply budget to the unknown price of %s %q", kind, resourceID))
+	}
+
+	if limits.Currency != "" && !strings.EqualFold(currency, limits.Currency) {
+		return reservation{}, newPriceError(fmt.Sprintf("Cannot apply %s budget to a %s price", limits.Currency, currency))
+	}
+
+	if limits.EnforceBalance {
+		organisation, err := g.organisations.GetDetails(g.organisationID, nil, nil)
+		if err != nil {
+			return reservation{}, err
+		}
+
+		balance := organisation.Data.Attributes.BillingBalance
+		if cost > balance {
+			exceeded := errors.NewBudgetExceededError(LimitBalance, balance, 0, cost, currency)
+			if !g.approve(kind, resourceID, exceeded) {
+				return reservation{}, exceeded
+			}
+		}
+	}
+
+	g.mu.Lock()
+	exceeded := g.check(cost, currency, time.Now())
+	if exceeded == nil {
+		defer g.mu.Unlock()
+		return g.add(cost), nil
+	}
+	g.mu.Unlock()
+
+	if !g.approve(kind, resourceID, exceeded) {
+		return reservation{}, exceeded
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.roll(time.Now())
+	return g.add(cost), nil
+}
+
+// add counts cost towards the current totals. The caller holds g.mu and has rolled the totals.
+func (g *Guard) add(cost float64) reservation {
+	g.dayTotal += cost
+	g.monTotal += cost
+
+	return reservation{cost: cost, day: g.day, month: g.month}
+}
+
+// release gives a reservation back, but only to the totals of the day and month it was
+// counted in, so a release after midnight cannot take the new day's total below zero.
+func (g *Guard) release(reserved reservation) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.roll(time.Now())
+
+	if g.day == reserved.day {
+		g.dayTotal = math.Max(0, g.dayTotal-reserved.cost)
+	}
+
+	if g.month == reserved.month {
+		g.monTotal = math.Max(0, g.monTotal-reserved.cost)
+	}
+}
+
+// productFor looks up the delivery product for a country, whatever the case of the map's keys.
+func productFor(deliveryProducts map[string]string, country string) (string, bool) {
+	for key, product := range deliveryProducts {
+		if strings.EqualFold(key, country) {
+			return product, true
+		}
+	}
+
+	return "", false
+}
+
+func (g *Guard) approve(kind, resourceID string, exceeded *errors.BudgetExceededError) bool {
+	if g.options.Approve == nil {
+		return false
+	}
+
+	return g.options.Approve(ApprovalRequest{Kind: kind, ResourceID: resourceID, Exceeded: exceeded})
+}
+
+func (g *Guard) check(cost float64, currency string, now time.Time) *errors.BudgetExceededError {
+	limits := g.options.Limits
+	g.roll(now)
+
+	switch {
+	case limits.PerSend > 0 && cost > limits.PerSend:
+		return errors.NewBudgetExceededError(LimitPerSend, limits.PerSend, 0, cost, currency)
+	case limits.PerDay > 0 && g.dayTotal+cost > limits.PerDay:
+		return errors.NewBudgetExceededError(LimitPerDay, limits.PerDay, g.dayTotal, cost, currency)
+	case limits.PerMonth > 0 && g.monTotal+cost > limits.PerMonth:
+		return errors.NewBudgetExceededError(LimitPerMonth, limits.PerMonth, g.monTotal, cost, currency)
+	}
+
+	return nil
+}
+
+// roll resets the totals when a new day or month has started.
+func (g *Guard) roll(now time.Time) {
+	local := now.In(g.options.Location)
+	day, month := local.Format("2006-01-02"), local.Format("2006-01")
+
+	if month != g.month {
+		g.month = month
+		g.monTotal = 0
+	}
+
+	if day != g.day {
+		g.day = day
+		g.dayTotal = 0
+	}
+}
+
+func newPriceError(message string) *errors.PingenError {
+	return errors.NewPingenError(message, "", http.StatusUnprocessableEntity, nil)
+}
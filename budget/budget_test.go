@@ -0,0 +1,277 @@
+package budget_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/budget"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const mockLetterResponse = `{
+	"data": {
+		"id": "letter-1",
+		"type": "letters",
+		"attributes": {"status": "valid", "country": "CH", "paper_types": ["normal"]},
+		"meta": {"abilities": {"self": {"submit": "ok"}}}
+	}
+}`
+
+type mockAPI struct {
+	server  *httptest.Server
+	price   string
+	balance float64
+	sends   int32
+	sendErr bool
+	// batchLetters lists the countries of the batch's letters.
+	batchLetters []string
+}
+
+func newMockAPI(t *testing.T) *mockAPI {
+	m := &mockAPI{price: "40.0", balance: 1000, batchLetters: []string{"CH"}}
+
+	m.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1" && r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `{"data": {"id": "org", "type": "organisations", "attributes": {"billing_currency": "CHF", "billing_balance": %v}}}`, m.balance)
+		case strings.HasSuffix(r.URL.Path, "/letters/price-calculator"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `{"data": {"attributes": {"currency": "CHF", "price": %s}}}`, m.price)
+		case strings.HasSuffix(r.URL.Path, "/send"):
+			atomic.AddInt32(&m.sends, 1)
+			if m.sendErr {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"errors": [{"detail": "mock failure"}]}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			if strings.Contains(r.URL.Path, "/batches/") {
+				_, _ = w.Write([]byte(`{"data": {"id": "batch-1", "type": "batches"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(mockLetterResponse))
+		case strings.HasSuffix(r.URL.Path, "/letters") && r.Method == http.MethodGet:
+			assert.Contains(t, r.URL.Query().Get("filter"), `"batch_id":"batch-1"`)
+
+			items := make([]string, len(m.batchLetters))
+			for i, country := range m.batchLetters {
+				items[i] = fmt.Sprintf(`{"id": "letter-%d", "type": "letters", "attributes": {"status": "valid", "country": "%s", "paper_types": ["normal"]}, "relationships": {"batch": {"data": {"id": "batch-1", "type": "batches"}}}}`, i+1, country)
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `{"data": [%s], "meta": {"current_page": 1, "last_page": 1}}`, strings.Join(items, ","))
+		case strings.Contains(r.URL.Path, "/letters/"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(mockLetterResponse))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	return m
+}
+
+func setupGuard(apiBaseURL string, options budget.Options) *budget.Guard {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(apiBaseURL)
+	apiRequestor := api.NewAPIRequestor("dummyToken", config)
+
+	return budget.NewGuard("testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", apiRequestor, options)
+}
+
+func TestGuard_SendLetterWithinBudget(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	guard := setupGuard(mock.server.URL, budget.Options{
+		Limits: budget.Limits{Currency: "CHF", PerSend: 50, PerDay: 100, PerMonth: 1000},
+	})
+
+	response, err := guard.SendLetter("letter-1", "cheap", "simplex", "grayscale")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "letter-1", response.Data.ID)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&mock.sends))
+
+	day, month := guard.Spent()
+	assert.Equal(t, 40.0, day)
+	assert.Equal(t, 40.0, month)
+}
+
+func TestGuard_PerSendLimit(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.price = "60.0"
+	defer mock.server.Close()
+
+	guard := setupGuard(mock.server.URL, budget.Options{Limits: budget.Limits{PerSend: 50}})
+
+	_, err := guard.SendLetter("letter-1", "cheap", "simplex", "grayscale")
+
+	exceeded, ok := err.(*errors.BudgetExceededError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusPaymentRequired, exceeded.StatusCode)
+	assert.Equal(t, budget.LimitPerSend, exceeded.Limit)
+	assert.Equal(t, 60.0, exceeded.Cost)
+	assert.Equal(t, "per_send", exceeded.JSONBody.(map[string]interface{})["limit"])
+	assert.Equal(t, int32(0), atomic.LoadInt32(&mock.sends))
+}
+
+func TestGuard_DailyLimitAcrossSends(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	guard := setupGuard(mock.server.URL, budget.Options{Limits: budget.Limits{PerDay: 100}})
+
+	_, err := guard.SendBatch("batch-1", map[string]string{"ch": "cheap"}, "simplex", "grayscale")
+	assert.Nil(t, err)
+	_, err = guard.SendLetter("letter-1", "cheap", "simplex", "grayscale")
+	assert.Nil(t, err)
+
+	_, err = guard.SendLetter("letter-1", "cheap", "simplex", "grayscale")
+	assert.IsType(t, &errors.BudgetExceededError{}, err)
+	assert.Contains(t, err.Error(), "per_day limit of 100.00 (already spent 80.00)")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&mock.sends))
+}
+
+func TestGuard_MonthlyLimitIncludesCarriedSpending(t *testing.T) {
+	guard := setupGuard("http://localhost", budget.Options{Limits: budget.Limits{PerMonth: 500}})
+	guard.AddSpending(480)
+
+	exceeded := guard.Check(40, "CHF")
+
+	assert.NotNil(t, exceeded)
+	assert.Equal(t, budget.LimitPerMonth, exceeded.Limit)
+	assert.Equal(t, 480.0, exceeded.Spent)
+	assert.Nil(t, guard.Check(20, "CHF"))
+}
+
+func TestGuard_Approval(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.price = "60.0"
+	defer mock.server.Close()
+
+	var requests []budget.ApprovalRequest
+	guard := setupGuard(mock.server.URL, budget.Options{
+		Limits: budget.Limits{PerSend: 50},
+		Approve: func(request budget.ApprovalRequest) bool {
+			requests = append(requests, request)
+			return request.ResourceID == "letter-1"
+		},
+	})
+
+	_, err := guard.SendLetter("letter-1", "cheap", "simplex", "grayscale")
+	assert.Nil(t, err)
+
+	_, err = guard.SendBatch("batch-1", map[string]string{"ch": "cheap"}, "simplex", "grayscale")
+	assert.IsType(t, &errors.BudgetExceededError{}, err)
+
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "letter", requests[0].Kind)
+	assert.Equal(t, 60.0, requests[0].Exceeded.Cost)
+	assert.Equal(t, "batch", requests[1].Kind)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&mock.sends))
+
+	day, _ := guard.Spent()
+	assert.Equal(t, 60.0, day)
+}
+
+func TestGuard_Balance(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.balance = 25
+	defer mock.server.Close()
+
+	guard := setupGuard(mock.server.URL, budget.Options{Limits: budget.Limits{EnforceBalance: true}})
+
+	_, err := guard.SendLetter("letter-1", "cheap", "simplex", "grayscale")
+
+	exceeded, ok := err.(*errors.BudgetExceededError)
+	assert.True(t, ok)
+	assert.Equal(t, budget.LimitBalance, exceeded.Limit)
+	assert.Equal(t, 25.0, exceeded.Allowed)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&mock.sends))
+}
+
+func TestGuard_CurrencyMismatch(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	guard := setupGuard(mock.server.URL, budget.Options{Limits: budget.Limits{Currency: "EUR", PerSend: 50}})
+
+	_, err := guard.SendLetter("letter-1", "cheap", "simplex", "grayscale")
+
+	pingenErr, ok := err.(*errors.PingenError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, pingenErr.StatusCode)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&mock.sends))
+}
+
+func TestGuard_FailedSendReleasesBudget(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.sendErr = true
+	defer mock.server.Close()
+
+	guard := setupGuard(mock.server.URL, budget.Options{Limits: budget.Limits{PerDay: 100}})
+
+	_, err := guard.SendLetter("letter-1", "cheap", "simplex", "grayscale")
+	pingenErr, ok := err.(*errors.PingenError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, pingenErr.StatusCode)
+
+	day, month := guard.Spent()
+	assert.Equal(t, 0.0, day)
+	assert.Equal(t, 0.0, month)
+
+	var exceeded *errors.BudgetExceededError = guard.Check(100, "CHF")
+	assert.Nil(t, exceeded)
+}
+
+func TestGuard_BatchPricedWithSendOptions(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.batchLetters = []string{"CH", "DE", "CH"}
+	defer mock.server.Close()
+
+	guard := setupGuard(mock.server.URL, budget.Options{Limits: budget.Limits{PerSend: 100}})
+
+	_, err := guard.SendBatch("batch-1", map[string]string{"ch": "cheap", "de": "fast"}, "duplex", "color")
+
+	exceeded, ok := err.(*errors.BudgetExceededError)
+	assert.True(t, ok)
+	assert.Equal(t, 120.0, exceeded.Cost)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&mock.sends))
+
+	_, err = guard.SendBatch("batch-1", map[string]string{"ch": "cheap"}, "duplex", "color")
+
+	pingenErr, ok := err.(*errors.PingenError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, pingenErr.StatusCode)
+	assert.Contains(t, pingenErr.Message, "No delivery product for DE letters")
+}
+
+func TestGuard_UnknownPriceIsRefused(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.price = "0"
+	defer mock.server.Close()
+
+	guard := setupGuard(mock.server.URL, budget.Options{Limits: budget.Limits{PerDay: 100}})
+
+	_, err := guard.SendBatch("batch-1", map[string]string{"ch": "cheap"}, "simplex", "grayscale")
+	pingenErr, ok := err.(*errors.PingenError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnprocessableEntity, pingenErr.StatusCode)
+
+	mock.batchLetters = nil
+	_, err = guard.SendBatch("batch-1", map[string]string{"ch": "cheap"}, "simplex", "grayscale")
+	assert.Contains(t, err.Error(), "without letters")
+
+	_, err = guard.SendLetter("letter-1", "cheap", "simplex", "grayscale")
+	assert.IsType(t, &errors.PingenError{}, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&mock.sends))
+}
@@ -0,0 +1,233 @@
+package bulk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+)
+
+// Job describes a single letter to upload, create and send once Pingen has validated it.
+// ID is chosen by the caller and only used to correlate results.
+type Job struct {
+	ID               string
+	PathToFile       string
+	FileOriginalName string
+	AddressPosition  string
+	DeliveryProduct  string
+	PrintMode        string
+	PrintSpectrum    string
+	SenderAddress    string
+	MetaData         map[string]interface{}
+	Relationships    map[string]interface{}
+}
+
+// Result reports whether a job's letter was sent. Err is nil once the send was accepted;
+// otherwise it says which step failed, and Letter holds the letter as it was last seen.
+type Result struct {
+	Job      Job
+	Letter   letters.LetterResponse
+	Err      *errors.PingenError
+	Duration time.Duration
+}
+
+type Options struct {
+	// Workers is the number of jobs processed concurrently. Defaults to 1.
+	Workers int
+	// JobsPerSecond is shared by all workers. Zero disables rate limiting.
+	JobsPerSecond float64
+	// PollInterval, ValidationTimeout and DeleteOnFailure are passed on to letters.SendPDF.
+	PollInterval      time.Duration
+	ValidationTimeout time.Duration
+	DeleteOnFailure   bool
+}
+
+type Sender struct {
+	letterClient *letters.Letters
+	workers      int
+	limiter      *time.Ticker
+	options      Options
+
+	mu     sync.Mutex
+	paused bool
+	resume chan struct{}
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func NewSender(letterClient *letters.Letters, options Options) *Sender {
+	workers := options.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	sender := &Sender{
+		letterClient: letterClient,
+		workers:      workers,
+		options:      options,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+
+	if options.JobsPerSecond > 0 {
+		sender.limiter = time.NewTicker(time.Duration(float64(time.Second) / options.JobsPerSecond))
+	}
+
+	return sender
+}
+
+// Start processes jobs until the jobs channel is closed or Shutdown is called.
+// The returned channel is closed once every started job has reported its result;
+// it must be drained, otherwise workers block. Start must only be called once.
+func (s *Sender) Start(jobs <-chan Job) <-chan Result {
+	results := make(chan Result)
+
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.work(jobs, results)
+	}
+
+	go func() {
+		s.wg.Wait()
+		if s.limiter != nil {
+			s.limiter.Stop()
+		}
+		close(results)
+		close(s.done)
+	}()
+
+	return results
+}
+
+// Pause stops workers from picking up new jobs. Jobs already in flight are finished.
+func (s *Sender) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.paused {
+		return
+	}
+
+	s.paused = true
+	s.resume = make(chan struct{})
+}
+
+func (s *Sender) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.paused {
+		return
+	}
+
+	s.paused = false
+	close(s.resume)
+}
+
+func (s *Sender) IsPaused() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.paused
+}
+
+// Shutdown stops picking up new jobs and waits for in-flight jobs to finish,
+// or for ctx to be done. Jobs left in the jobs channel are not consumed.
+func (s *Sender) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Sender) work(jobs <-chan Job, results chan<- Result) {
+	defer s.wg.Done()
+
+	for {
+		if !s.waitUntilResumed() {
+			return
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case job, ok := <-jobs:
+			if !ok {
+				return
+			}
+			s.waitForSlot()
+			results <- s.process(job)
+		}
+	}
+}
+
+// waitUntilResumed blocks while the sender is paused. It reports false once the sender is shut down.
+func (s *Sender) waitUntilResumed() bool {
+	for {
+		s.mu.Lock()
+		paused, resume := s.paused, s.resume
+		s.mu.Unlock()
+
+		if !paused {
+			break
+		}
+
+		select {
+		case <-s.stop:
+			return false
+		case <-resume:
+		}
+	}
+
+	select {
+	case <-s.stop:
+		return false
+	default:
+		return true
+	}
+}
+
+// waitForSlot blocks until the rate limiter grants a slot. Workers only ask for one once
+// they hold a job, so idle workers cannot stock up slots and burst past the limit when
+// jobs arrive. A received job counts as in flight and is processed even during Shutdown.
+func (s *Sender) waitForSlot() {
+	if s.limiter != nil {
+		<-s.limiter.C
+	}
+}
+
+func (s *Sender) process(job Job) Result {
+	started := time.Now()
+
+	letter, err := s.letterClient.SendPDF(job.PathToFile, letters.SendPDFOptions{
+		FileOriginalName:  job.FileOriginalName,
+		AddressPosition:   job.AddressPosition,
+		DeliveryProduct:   job.DeliveryProduct,
+		PrintMode:         job.PrintMode,
+		PrintSpectrum:     job.PrintSpectrum,
+		SenderAddress:     job.SenderAddress,
+		MetaData:          job.MetaData,
+		Relationships:     job.Relationships,
+		PollInterval:      s.options.PollInterval,
+		ValidationTimeout: s.options.ValidationTimeout,
+		DeleteOnFailure:   s.options.DeleteOnFailure,
+	})
+
+	return Result{
+		Job:      job,
+		Letter:   letter,
+		Err:      err,
+		Duration: time.Since(started),
+	}
+}
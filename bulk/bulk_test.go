@@ -0,0 +1,327 @@
+package bulk_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/bulk"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAPI struct {
+	server     *httptest.Server
+	created    int32
+	sent       int32
+	inFlight   int32
+	maxSeen    int32
+	delay      time.Duration
+	failFor    string
+	rejectSend string
+	bodies     sync.Map
+}
+
+func newMockAPI(t *testing.T) *mockAPI {
+	m := &mockAPI{}
+
+	m.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/file-upload":
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `{"data": {"attributes": {"url": "%s/upload", "url_signature": "mock-signature"}}}`, m.server.URL)
+		case r.URL.Path == "/upload":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/letters/letter-"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `{"data": {"id": "%s", "type": "letters", "attributes": {"status": "valid"}, "meta": {"abilities": {"self": {"submit": "ok"}}}}}`, path.Base(r.URL.Path))
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/send"):
+			letterID := path.Base(path.Dir(r.URL.Path))
+			body, _ := m.bodies.Load(letterID)
+
+			if m.rejectSend != "" && strings.Contains(body.(string), m.rejectSend) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"errors": [{"detail": "not sendable"}]}`))
+				return
+			}
+
+			atomic.AddInt32(&m.sent, 1)
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `{"data": {"id": "%s", "type": "letters", "attributes": {"status": "submitted"}}}`, letterID)
+		case strings.HasSuffix(r.URL.Path, "/letters"):
+			current := atomic.AddInt32(&m.inFlight, 1)
+			defer atomic.AddInt32(&m.inFlight, -1)
+
+			for {
+				seen := atomic.LoadInt32(&m.maxSeen)
+				if current <= seen || atomic.CompareAndSwapInt32(&m.maxSeen, seen, current) {
+					break
+				}
+			}
+
+			time.Sleep(m.delay)
+
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"auto_send":false`)
+
+			if m.failFor != "" && strings.Contains(string(body), m.failFor) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"errors": [{"detail": "invalid"}]}`))
+				return
+			}
+
+			n := atomic.AddInt32(&m.created, 1)
+			m.bodies.Store(fmt.Sprintf("letter-%d", n), string(body))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"data": {"id": "letter-%d", "type": "letters", "attributes": {"status": "validating"}}}`, n)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	return m
+}
+
+func setupSender(apiBaseURL string, options bulk.Options) *bulk.Sender {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(apiBaseURL)
+	apiRequestor := api.NewAPIRequestor("dummyToken", config)
+
+	return bulk.NewSender(letters.NewLetters("testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", apiRequestor), options)
+}
+
+func newJob(id string) bulk.Job {
+	return bulk.Job{
+		ID:               id,
+		PathToFile:       "testFile.pdf",
+		FileOriginalName: id + ".pdf",
+		AddressPosition:  "left",
+		DeliveryProduct:  "cheap",
+		PrintMode:        "simplex",
+		PrintSpectrum:    "grayscale",
+	}
+}
+
+func feed(ids ...string) <-chan bulk.Job {
+	jobs := make(chan bulk.Job, len(ids))
+	for _, id := range ids {
+		jobs <- newJob(id)
+	}
+	close(jobs)
+
+	return jobs
+}
+
+func TestSender_ProcessesAllJobs(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.delay = 20 * time.Millisecond
+	defer mock.server.Close()
+
+	sender := setupSender(mock.server.URL, bulk.Options{Workers: 3})
+
+	var ids []string
+	for result := range sender.Start(feed("a", "b", "c", "d", "e", "f")) {
+		assert.Nil(t, result.Err)
+		assert.NotEmpty(t, result.Letter.Data.ID)
+		assert.Equal(t, letters.StatusSubmitted, result.Letter.Status())
+		ids = append(ids, result.Job.ID)
+	}
+
+	sort.Strings(ids)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f"}, ids)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&mock.created))
+	assert.Equal(t, int32(6), atomic.LoadInt32(&mock.sent))
+	assert.LessOrEqual(t, atomic.LoadInt32(&mock.maxSeen), int32(3))
+	assert.Greater(t, atomic.LoadInt32(&mock.maxSeen), int32(1))
+}
+
+func TestSender_ReportsPerJobErrors(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.failFor = `"file_original_name":"bad.pdf"`
+	defer mock.server.Close()
+
+	sender := setupSender(mock.server.URL, bulk.Options{Workers: 2})
+
+	failed := map[string]int{}
+	for result := range sender.Start(feed("good", "bad", "other")) {
+		if result.Err != nil {
+			failed[result.Job.ID] = result.Err.StatusCode
+		}
+	}
+
+	assert.Equal(t, map[string]int{"bad": http.StatusUnprocessableEntity}, failed)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&mock.created))
+}
+
+func TestSender_ReportsSendFailures(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.rejectSend = `"file_original_name":"refused.pdf"`
+	defer mock.server.Close()
+
+	sender := setupSender(mock.server.URL, bulk.Options{Workers: 2})
+
+	failed := map[string]int{}
+	for result := range sender.Start(feed("good", "refused", "other")) {
+		if result.Err != nil {
+			failed[result.Job.ID] = result.Err.StatusCode
+		}
+	}
+
+	assert.Equal(t, map[string]int{"refused": http.StatusUnprocessableEntity}, failed)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&mock.created))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&mock.sent))
+}
+
+func TestSender_RateLimit(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	sender := setupSender(mock.server.URL, bulk.Options{Workers: 4, JobsPerSecond: 50})
+
+	started := time.Now()
+	count := 0
+	for range sender.Start(feed("a", "b", "c", "d", "e")) {
+		count++
+	}
+
+	assert.Equal(t, 5, count)
+	assert.GreaterOrEqual(t, time.Since(started), 80*time.Millisecond)
+}
+
+func TestSender_RateLimitAfterIdle(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	sender := setupSender(mock.server.URL, bulk.Options{Workers: 4, JobsPerSecond: 20})
+
+	jobs := make(chan bulk.Job)
+	results := sender.Start(jobs)
+
+	time.Sleep(300 * time.Millisecond)
+
+	started := time.Now()
+	go func() {
+		for _, id := range []string{"a", "b", "c", "d"} {
+			jobs <- newJob(id)
+		}
+		close(jobs)
+	}()
+
+	var last time.Duration
+	count := 0
+	for range results {
+		count++
+		last = time.Since(started)
+	}
+
+	assert.Equal(t, 4, count)
+	assert.GreaterOrEqual(t, last, 120*time.Millisecond)
+}
+
+func TestSender_PauseAndResume(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	sender := setupSender(mock.server.URL, bulk.Options{Workers: 2})
+	sender.Pause()
+	assert.True(t, sender.IsPaused())
+
+	results := sender.Start(feed("a", "b"))
+
+	select {
+	case <-results:
+		t.Fatal("expected no results while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&mock.created))
+
+	sender.Resume()
+	assert.False(t, sender.IsPaused())
+
+	count := 0
+	for range results {
+		count++
+	}
+	assert.Equal(t, 2, count)
+}
+
+func TestSender_Shutdown(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.delay = 30 * time.Millisecond
+	defer mock.server.Close()
+
+	sender := setupSender(mock.server.URL, bulk.Options{Workers: 1})
+
+	jobs := make(chan bulk.Job)
+	results := sender.Start(jobs)
+
+	var wg sync.WaitGroup
+	var received []bulk.Result
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for result := range results {
+			received = append(received, result)
+		}
+	}()
+
+	jobs <- newJob("in-flight")
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.Nil(t, sender.Shutdown(ctx))
+	wg.Wait()
+
+	assert.Len(t, received, 1)
+	assert.Equal(t, "in-flight", received[0].Job.ID)
+	assert.Nil(t, received[0].Err)
+}
+
+func TestSender_ShutdownWhilePaused(t *testing.T) {
+	sender := setupSender("http://invalid-url", bulk.Options{Workers: 2})
+	sender.Pause()
+
+	results := sender.Start(make(chan bulk.Job))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.Nil(t, sender.Shutdown(ctx))
+
+	_, open := <-results
+	assert.False(t, open)
+}
+
+func TestSender_ShutdownTimeout(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.delay = 200 * time.Millisecond
+	defer mock.server.Close()
+
+	sender := setupSender(mock.server.URL, bulk.Options{})
+
+	jobs := make(chan bulk.Job, 1)
+	jobs <- newJob("slow")
+	results := sender.Start(jobs)
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, sender.Shutdown(ctx))
+
+	for range results {
+	}
+}
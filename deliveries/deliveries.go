@@ -0,0 +1,292 @@
+package deliveries
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/ebills"
+	"github.com/pingencom/pingen2-sdk-go/emails"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+)
+
+type Channel string
+
+const (
+	ChannelLetter Channel = "letter"
+	ChannelEbill  Channel = "ebill"
+	ChannelEmail  Channel = "email"
+)
+
+// Recipient says how a document reaches one recipient. Channels are tried in order;
+// a channel is only left for the next one when the API rejects the delivery.
+type Recipient struct {
+	// Channels in order of preference. Defaults to ChannelLetter.
+	Channels []Channel
+	// FallbackToLetter adds ChannelLetter as the last channel if it is not listed.
+	FallbackToLetter bool
+	// Invoice is required for ChannelEbill.
+	Invoice *ebills.Invoice
+	// EmailAddress is required for ChannelEmail.
+	EmailAddress string
+	// Letter holds the print and delivery options for ChannelLetter. Its FileOriginalName is
+	// set from Deliver when empty.
+	Letter letters.SendPDFOptions
+	// MetaData is added to ebill and email deliveries.
+	MetaData map[string]interface{}
+	// PollInterval is the delay between status checks while an ebill or email validates. Defaults to 2s.
+	PollInterval time.Duration
+	// ValidationTimeout bounds the wait for ebill and email validation. Defaults to 5 minutes.
+	ValidationTimeout time.Duration
+}
+
+// Attempt records a channel that was tried and the error it failed with.
+type Attempt struct {
+	Channel Channel
+	Err     *errors.PingenError
+}
+
+// Result describes the delivery that was made. Rejected lists the channels that failed, in the order they were tried.
+type Result struct {
+	Channel  Channel
+	ID       string
+	Status   string
+	Rejected []Attempt
+}
+
+// rejectedStatuses are the ebill and email statuses validation can end in that rule out sending.
+var rejectedStatuses = map[string]bool{
+	"invalid":  true,
+	"rejected": true,
+}
+
+type Router struct {
+	letterClient *letters.Letters
+	ebillClient  *ebills.Ebills
+	emailClient  *emails.Emails
+}
+
+func NewRouter(organisationID string, apiRequestor *api.APIRequestor) *Router {
+	return &Router{
+		letterClient: letters.NewLetters(organisationID, apiRequestor),
+		ebillClient:  ebills.NewEbills(organisationID, apiRequestor),
+		emailClient:  emails.NewEmails(organisationID, apiRequestor),
+	}
+}
+
+// Deliver sends the PDF through the recipient's first channel that accepts it. Like letters,
+// ebills and emails are only sent once Pingen has validated them. When a channel is rejected
+// (a 4xx other than 408 or 429, including a recipient lacking the channel's details and a
+// document that validation left invalid or rejected) the next channel is tried; any other
+// error is returned straight away, since the delivery may have gone through. If every channel
+// is rejected, the last rejection is returned along with the result listing all attempts.
+// An ebill or email that was created before its channel was rejected is deleted again.
+func (r *Router) Deliver(pathToFile, fileOriginalName string, recipient Recipient) (Result, *errors.PingenError) {
+	var result Result
+
+	for _, channel := range channels(recipient) {
+		id, status, err := r.deliver(channel, pathToFile, fileOriginalName, recipient)
+		if err == nil {
+			result.Channel = channel
+			result.ID = id
+			result.Status = status
+			return result, nil
+		}
+
+		result.Rejected = append(result.Rejected, Attempt{Channel: channel, Err: err})
+
+		if !errors.IsRejection(err) {
+			return result, err
+		}
+	}
+
+	return result, result.Rejected[len(result.Rejected)-1].Err
+}
+
+func (r *Router) deliver(channel Channel, pathToFile, fileOriginalName string, recipient Recipient) (string, string, *errors.PingenError) {
+	switch channel {
+	case ChannelLetter:
+		options := recipient.Letter
+		if options.FileOriginalName == "" {
+			options.FileOriginalName = fileOriginalName
+		}
+
+		letter, err := r.letterClient.SendPDF(pathToFile, options)
+		if err != nil {
+			return "", "", err
+		}
+		return letter.Data.ID, letter.Data.Attributes.Status, nil
+
+	case ChannelEbill:
+		if recipient.Invoice == nil {
+			return "", "", newRecipientError(channel, "invoice")
+		}
+		if err := recipient.Invoice.Validate(); err != nil {
+			return "", "", err
+		}
+
+		return r.createAndSend(channel, recipient, document{
+			create: func() (string, *errors.PingenError) {
+				ebill, err := r.ebillClient.UploadAndCreate(pathToFile, fileOriginalName, false, metaData(recipient, recipient.Invoice.MetaData()), nil)
+				return ebill.Data.ID, err
+			},
+			validate: func(id string, pollInterval, timeout time.Duration) (string, bool, *errors.PingenError) {
+				ebill, err := r.ebillClient.WaitUntilValidated(id, pollInterval, timeout)
+				return ebill.Data.Attributes.Status, ebill.Can(ebills.AbilitySubmit), err
+			},
+			send: func(id string) (string, *errors.PingenError) {
+				ebill, err := r.ebillClient.Send(id)
+				return ebill.Data.Attributes.Status, err
+			},
+			remove: func(id string) {
+				_, _ = r.ebillClient.Delete(id)
+			},
+		})
+
+	case ChannelEmail:
+		if recipient.EmailAddress == "" {
+			return "", "", newRecipientError(channel, "email_address")
+		}
+
+		return r.createAndSend(channel, recipient, document{
+			create: func() (string, *errors.PingenError) {
+				email, err := r.emailClient.UploadAndCreate(pathToFile, fileOriginalName, false, metaData(recipient, map[string]interface{}{
+					"recipient_identifier": recipient.EmailAddress,
+				}), nil)
+				return email.Data.ID, err
+			},
+			validate: func(id string, pollInterval, timeout time.Duration) (string, bool, *errors.PingenError) {
+				email, err := r.emailClient.WaitUntilValidated(id, pollInterval, timeout)
+				return email.Data.Attributes.Status, email.Can(emails.AbilitySubmit), err
+			},
+			send: func(id string) (string, *errors.PingenError) {
+				email, err := r.emailClient.Send(id)
+				return email.Data.Attributes.Status, err
+			},
+			remove: func(id string) {
+				_, _ = r.emailClient.Delete(id)
+			},
+		})
+
+	default:
+		return "", "", errors.NewPingenError(
+			fmt.Sprintf("Unknown delivery channel %q", channel),
+			"",
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+}
+
+// document holds the calls that differ between ebills and emails, which are otherwise
+// delivered the same way.
+type document struct {
+	create   func() (string, *errors.PingenError)
+	validate func(id string, pollInterval, timeout time.Duration) (status string, submittable bool, err *errors.PingenError)
+	send     func(id string) (string, *errors.PingenError)
+	remove   func(id string)
+}
+
+// createAndSend creates the document without sending it, waits until Pingen has validated it and
+// sends it. A document that is rejected after it was created is deleted again, so falling back to
+// the next channel does not leave it behind.
+func (r *Router) createAndSend(channel Channel, recipient Recipient, doc document) (string, string, *errors.PingenError) {
+	id, err := doc.create()
+	if err != nil {
+		return "", "", err
+	}
+
+	status, err := r.validateAndSend(channel, recipient, id, doc)
+	if err != nil {
+		if errors.IsRejection(err) && !api.IsDryRunID(id) {
+			doc.remove(id)
+		}
+		return "", "", err
+	}
+
+	return id, status, nil
+}
+
+func (r *Router) validateAndSend(channel Channel, recipient Recipient, id string, doc document) (string, *errors.PingenError) {
+	// Dry-run documents were never created, so only the send is simulated.
+	if !api.IsDryRunID(id) {
+		pollInterval, timeout := validationTiming(recipient)
+
+		status, submittable, err := doc.validate(id, pollInterval, timeout)
+		if err != nil {
+			return "", err
+		}
+
+		if rejectedStatuses[status] || !submittable {
+			return "", newNotSendableError(channel, id, status)
+		}
+	}
+
+	return doc.send(id)
+}
+
+func channels(recipient Recipient) []Channel {
+	result := append([]Channel(nil), recipient.Channels...)
+
+	hasLetter := false
+	for _, channel := range result {
+		hasLetter = hasLetter || channel == ChannelLetter
+	}
+
+	if len(result) == 0 || (recipient.FallbackToLetter && !hasLetter) {
+		result = append(result, ChannelLetter)
+	}
+
+	return result
+}
+
+// metaData merges the recipient's meta data with the channel's own, which wins on conflicts.
+func metaData(recipient Recipient, channel map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for key, value := range recipient.MetaData {
+		merged[key] = value
+	}
+	for key, value := range channel {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func validationTiming(recipient Recipient) (pollInterval, timeout time.Duration) {
+	pollInterval, timeout = recipient.PollInterval, recipient.ValidationTimeout
+	if pollInterval <= 0 {
+		pollInterval = 2 * time.Second
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Minute
+	}
+
+	return pollInterval, timeout
+}
+
+func newRecipientError(channel Channel, field string) *errors.PingenError {
+	return errors.NewPingenError(
+		fmt.Sprintf("Recipient has no %s for %s delivery", field, channel),
+		fmt.Sprintf(`{%q: "is required"}`, field),
+		http.StatusUnprocessableEntity,
+		nil,
+	)
+}
+
+func newNotSendableError(channel Channel, id, status string) *errors.PingenError {
+	body, _ := json.Marshal(map[string]string{
+		"id":     id,
+		"status": status,
+	})
+
+	return errors.NewPingenError(
+		fmt.Sprintf("%s %s cannot be sent after validation, status %q", channel, id, status),
+		string(body),
+		http.StatusUnprocessableEntity,
+		nil,
+	)
+}
@@ -0,0 +1,265 @@
+package deliveries_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/deliveries"
+	"github.com/pingencom/pingen2-sdk-go/ebills"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+const organisationPath = "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1"
+
+func letterResponse(status string) string {
+	return fmt.Sprintf(`{"data": {"id": "letter-id", "type": "letters", "attributes": {"status": "%s", "country": "CH"}}}`, status)
+}
+
+func deliveryResponse(id, resourceType, status string) string {
+	return fmt.Sprintf(`{"data": {"id": "%s", "type": "%s", "attributes": {"status": "%s"}}}`, id, resourceType, status)
+}
+
+func setupRouter(t *testing.T, handle func(w http.ResponseWriter, r *http.Request) bool) (*deliveries.Router, *[]string, string) {
+	var requests []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.URL.Path {
+		case "/file-upload":
+			_, _ = fmt.Fprintf(w, `{"data": {"attributes": {"url": "%s/upload", "url_signature": "signature"}}}`, server.URL)
+			return
+		case "/upload":
+			_, _ = io.ReadAll(r.Body)
+			return
+		}
+
+		if !handle(w, r) {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(server.URL)
+	apiRequestor := api.NewAPIRequestor("dummyToken", config)
+
+	path := filepath.Join(t.TempDir(), "invoice.pdf")
+	assert.Nil(t, os.WriteFile(path, []byte("%PDF-"), 0o600))
+
+	return deliveries.NewRouter("testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", apiRequestor), &requests, path
+}
+
+func invoice() *ebills.Invoice {
+	return &ebills.Invoice{
+		RecipientIdentifier: "41100010014282213",
+		InvoiceNumber:       "8051",
+		InvoiceDate:         time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		InvoiceDueDate:      time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC),
+		InvoiceValue:        100,
+		InvoiceCurrency:     "CHF",
+	}
+}
+
+func TestDeliver_Ebill(t *testing.T) {
+	polls := 0
+	router, requests, path := setupRouter(t, func(w http.ResponseWriter, r *http.Request) bool {
+		switch r.Method + " " + r.URL.Path {
+		case "POST " + organisationPath + "/deliveries/ebills":
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"auto_send":false`)
+			assert.Contains(t, string(body), `"invoice_number":"8051"`)
+			assert.Contains(t, string(body), `"customer":"42"`)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(deliveryResponse("ebill-id", "ebills", "validating")))
+		case "GET " + organisationPath + "/deliveries/ebills/ebill-id":
+			polls++
+			if polls == 1 {
+				_, _ = w.Write([]byte(deliveryResponse("ebill-id", "ebills", "validating")))
+				return true
+			}
+			_, _ = w.Write([]byte(deliveryResponse("ebill-id", "ebills", "valid")))
+		case "PATCH " + organisationPath + "/deliveries/ebills/ebill-id/send":
+			_, _ = w.Write([]byte(deliveryResponse("ebill-id", "ebills", "submitted")))
+		default:
+			return false
+		}
+		return true
+	})
+
+	result, err := router.Deliver(path, "invoice.pdf", deliveries.Recipient{
+		Channels:         []deliveries.Channel{deliveries.ChannelEbill},
+		FallbackToLetter: true,
+		Invoice:          invoice(),
+		MetaData:         map[string]interface{}{"customer": "42"},
+		PollInterval:     time.Millisecond,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, deliveries.Result{Channel: deliveries.ChannelEbill, ID: "ebill-id", Status: "submitted"}, result)
+	assert.Contains(t, *requests, "PATCH "+organisationPath+"/deliveries/ebills/ebill-id/send")
+}
+
+func TestDeliver_InvalidAfterValidationFallsBack(t *testing.T) {
+	router, requests, path := setupRouter(t, func(w http.ResponseWriter, r *http.Request) bool {
+		switch r.Method + " " + r.URL.Path {
+		case "POST " + organisationPath + "/deliveries/emails":
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"auto_send":false`)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(deliveryResponse("email-id", "emails", "validating")))
+		case "GET " + organisationPath + "/deliveries/emails/email-id":
+			_, _ = w.Write([]byte(deliveryResponse("email-id", "emails", "invalid")))
+		case "DELETE " + organisationPath + "/deliveries/emails/email-id":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST " + organisationPath + "/letters":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(letterResponse("validating")))
+		case "GET " + organisationPath + "/letters/letter-id":
+			_, _ = w.Write([]byte(letterResponse("valid")))
+		case "PATCH " + organisationPath + "/letters/letter-id/send":
+			_, _ = w.Write([]byte(letterResponse("submitted")))
+		default:
+			return false
+		}
+		return true
+	})
+
+	result, err := router.Deliver(path, "invoice.pdf", deliveries.Recipient{
+		Channels:         []deliveries.Channel{deliveries.ChannelEmail},
+		FallbackToLetter: true,
+		EmailAddress:     "billing@example.com",
+		PollInterval:     time.Millisecond,
+		Letter:           letters.SendPDFOptions{PollInterval: time.Millisecond},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, deliveries.ChannelLetter, result.Channel)
+	assert.Len(t, result.Rejected, 1)
+	assert.Equal(t, deliveries.ChannelEmail, result.Rejected[0].Channel)
+	assert.Equal(t, http.StatusUnprocessableEntity, result.Rejected[0].Err.StatusCode)
+	assert.Contains(t, result.Rejected[0].Err.Message, `status "invalid"`)
+	assert.NotContains(t, *requests, "PATCH "+organisationPath+"/deliveries/emails/email-id/send")
+	assert.Contains(t, *requests, "DELETE "+organisationPath+"/deliveries/emails/email-id")
+}
+
+func TestDeliver_RejectedSendDeletesEbill(t *testing.T) {
+	router, requests, path := setupRouter(t, func(w http.ResponseWriter, r *http.Request) bool {
+		switch r.Method + " " + r.URL.Path {
+		case "POST " + organisationPath + "/deliveries/ebills":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(deliveryResponse("ebill-id", "ebills", "validating")))
+		case "GET " + organisationPath + "/deliveries/ebills/ebill-id":
+			_, _ = w.Write([]byte(deliveryResponse("ebill-id", "ebills", "valid")))
+		case "PATCH " + organisationPath + "/deliveries/ebills/ebill-id/send":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors": [{"title": "Recipient is not registered for eBill"}]}`))
+		case "DELETE " + organisationPath + "/deliveries/ebills/ebill-id":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			return false
+		}
+		return true
+	})
+
+	result, err := router.Deliver(path, "invoice.pdf", deliveries.Recipient{
+		Channels:     []deliveries.Channel{deliveries.ChannelEbill},
+		Invoice:      invoice(),
+		PollInterval: time.Millisecond,
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Len(t, result.Rejected, 1)
+	assert.Equal(t, "DELETE "+organisationPath+"/deliveries/ebills/ebill-id", (*requests)[len(*requests)-1])
+}
+
+func TestDeliver_FallbackToLetter(t *testing.T) {
+	router, requests, path := setupRouter(t, func(w http.ResponseWriter, r *http.Request) bool {
+		switch r.Method + " " + r.URL.Path {
+		case "POST " + organisationPath + "/deliveries/ebills":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors": [{"title": "Recipient is not registered for eBill"}]}`))
+		case "POST " + organisationPath + "/letters":
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(letterResponse("validating")))
+		case "GET " + organisationPath + "/letters/letter-id":
+			_, _ = w.Write([]byte(letterResponse("valid")))
+		case "PATCH " + organisationPath + "/letters/letter-id/send":
+			_, _ = w.Write([]byte(letterResponse("submitted")))
+		default:
+			return false
+		}
+		return true
+	})
+
+	result, err := router.Deliver(path, "invoice.pdf", deliveries.Recipient{
+		Channels:         []deliveries.Channel{deliveries.ChannelEmail, deliveries.ChannelEbill},
+		FallbackToLetter: true,
+		Invoice:          invoice(),
+		Letter: letters.SendPDFOptions{
+			AddressPosition: "left",
+			DeliveryProduct: "cheap",
+			PrintMode:       "simplex",
+			PrintSpectrum:   "grayscale",
+			PollInterval:    time.Millisecond,
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, deliveries.ChannelLetter, result.Channel)
+	assert.Equal(t, "letter-id", result.ID)
+	assert.Equal(t, "submitted", result.Status)
+	assert.Len(t, result.Rejected, 2)
+	assert.Equal(t, deliveries.ChannelEmail, result.Rejected[0].Channel)
+	assert.Equal(t, "Recipient has no email_address for email delivery", result.Rejected[0].Err.Message)
+	assert.Equal(t, deliveries.ChannelEbill, result.Rejected[1].Channel)
+	assert.Equal(t, http.StatusUnprocessableEntity, result.Rejected[1].Err.StatusCode)
+	assert.Contains(t, *requests, "PATCH "+organisationPath+"/letters/letter-id/send")
+}
+
+func TestDeliver_TransientErrorStops(t *testing.T) {
+	router, requests, path := setupRouter(t, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path != organisationPath+"/deliveries/emails" {
+			return false
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return true
+	})
+
+	result, err := router.Deliver(path, "invoice.pdf", deliveries.Recipient{
+		Channels:         []deliveries.Channel{deliveries.ChannelEmail},
+		FallbackToLetter: true,
+		EmailAddress:     "billing@example.com",
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, err.StatusCode)
+	assert.Equal(t, deliveries.Channel(""), result.Channel)
+	assert.Len(t, result.Rejected, 1)
+	assert.NotContains(t, *requests, "POST "+organisationPath+"/letters")
+}
+
+func TestDeliver_AllRejected(t *testing.T) {
+	router, _, path := setupRouter(t, func(w http.ResponseWriter, r *http.Request) bool { return false })
+
+	_, err := router.Deliver(path, "invoice.pdf", deliveries.Recipient{
+		Channels: []deliveries.Channel{deliveries.ChannelEbill, "fax"},
+	})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+	assert.Equal(t, `Unknown delivery channel "fax"`, err.Message)
+}
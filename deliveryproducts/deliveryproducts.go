@@ -0,0 +1,247 @@
+package deliveryproducts
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+type Product string
+
+const (
+	Fast       Product = "fast"
+	Cheap      Product = "cheap"
+	Bulk       Product = "bulk"
+	Premium    Product = "premium"
+	Registered Product = "registered"
+
+	PostAGA          Product = "postag_a"
+	PostAGB          Product = "postag_b"
+	PostAGB2         Product = "postag_b2"
+	PostAGAPlus      Product = "postag_aplus"
+	PostAGRegistered Product = "postag_registered"
+
+	DPAGStandard Product = "dpag_standard"
+	DPAGEconomy  Product = "dpag_economy"
+
+	IndpostMail      Product = "indpost_mail"
+	IndpostSpeedmail Product = "indpost_speedmail"
+)
+
+type PrintMode string
+
+const (
+	PrintModeSimplex PrintMode = "simplex"
+	PrintModeDuplex  PrintMode = "duplex"
+)
+
+type PrintSpectrum string
+
+const (
+	PrintSpectrumGrayscale PrintSpectrum = "grayscale"
+	PrintSpectrumColor     PrintSpectrum = "color"
+)
+
+// Rule says where and how a product can be used. Empty lists allow everything.
+type Rule struct {
+	Product        Product
+	Countries      []string
+	PrintModes     []PrintMode
+	PrintSpectrums []PrintSpectrum
+}
+
+func (r Rule) allowsCountry(country string) bool {
+	if len(r.Countries) == 0 {
+		return true
+	}
+
+	for _, allowed := range r.Countries {
+		if strings.EqualFold(allowed, country) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Rule) allowsPrintMode(printMode PrintMode) bool {
+	if len(r.PrintModes) == 0 {
+		return true
+	}
+
+	for _, allowed := range r.PrintModes {
+		if allowed == printMode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Rule) allowsPrintSpectrum(printSpectrum PrintSpectrum) bool {
+	if len(r.PrintSpectrums) == 0 {
+		return true
+	}
+
+	for _, allowed := range r.PrintSpectrums {
+		if allowed == printSpectrum {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Catalog knows which delivery products can be used for which countries and print options.
+type Catalog struct {
+	rules map[Product]Rule
+	order []Product
+}
+
+func NewCatalog(rules ...Rule) *Catalog {
+	catalog := &Catalog{rules: map[Product]Rule{}}
+
+	for _, rule := range rules {
+		if _, exists := catalog.rules[rule.Product]; !exists {
+			catalog.order = append(catalog.order, rule.Product)
+		}
+		catalog.rules[rule.Product] = rule
+	}
+
+	return catalog
+}
+
+// DefaultCatalog describes the products Pingen offers. The generic products are available
+// everywhere and mapped by Pingen to a carrier; carrier products only serve their home countries.
+// Swiss Post covers Liechtenstein as well, Indpost delivers in Switzerland only. Every product
+// prints on one or both sides, in grayscale or color.
+func DefaultCatalog() *Catalog {
+	swiss := []string{"CH", "LI"}
+	german := []string{"DE"}
+	indpost := []string{"CH"}
+
+	return NewCatalog(
+		defaultRule(Fast),
+		defaultRule(Cheap),
+		defaultRule(Bulk, swiss...),
+		defaultRule(Premium, swiss...),
+		defaultRule(Registered, swiss...),
+		defaultRule(PostAGA, swiss...),
+		defaultRule(PostAGB, swiss...),
+		defaultRule(PostAGB2, swiss...),
+		defaultRule(PostAGAPlus, swiss...),
+		defaultRule(PostAGRegistered, swiss...),
+		defaultRule(DPAGStandard, german...),
+		defaultRule(DPAGEconomy, german...),
+		defaultRule(IndpostMail, indpost...),
+		defaultRule(IndpostSpeedmail, indpost...),
+	)
+}
+
+func defaultRule(product Product, countries ...string) Rule {
+	return Rule{
+		Product:        product,
+		Countries:      countries,
+		PrintModes:     []PrintMode{PrintModeSimplex, PrintModeDuplex},
+		PrintSpectrums: []PrintSpectrum{PrintSpectrumGrayscale, PrintSpectrumColor},
+	}
+}
+
+// Products lists the products usable for country, in catalog order.
+func (c *Catalog) Products(country string) []Product {
+	var products []Product
+	for _, product := range c.order {
+		if c.rules[product].allowsCountry(country) {
+			products = append(products, product)
+		}
+	}
+
+	return products
+}
+
+// Validate checks product against the catalog. An empty print mode or print spectrum is not
+// checked. An empty country, e.g. of a letter whose address has not been read yet, only passes
+// products that are available everywhere.
+func (c *Catalog) Validate(product Product, country string, printMode PrintMode, printSpectrum PrintSpectrum) *errors.PingenError {
+	rule, ok := c.rules[product]
+
+	switch {
+	case !ok:
+		return newValidationError(fmt.Sprintf("Unknown delivery product %q", product), product, country)
+	case country == "" && !rule.allowsCountry(country):
+		return newValidationError(fmt.Sprintf("Delivery product %q cannot be checked without a country", product), product, country)
+	case !rule.allowsCountry(country):
+		return newValidationError(fmt.Sprintf("Delivery product %q is not available for %s", product, strings.ToUpper(country)), product, country)
+	case printMode != "" && !rule.allowsPrintMode(printMode):
+		return newValidationError(fmt.Sprintf("Delivery product %q does not support print mode %q", product, printMode), product, country)
+	case printSpectrum != "" && !rule.allowsPrintSpectrum(printSpectrum):
+		return newValidationError(fmt.Sprintf("Delivery product %q does not support print spectrum %q", product, printSpectrum), product, country)
+	}
+
+	return nil
+}
+
+// DeliveryProducts builds the delivery_products argument of batches.SendBatch, choosing for
+// every country the first of preferred that is available there.
+func (c *Catalog) DeliveryProducts(
+	countries []string,
+	printMode PrintMode,
+	printSpectrum PrintSpectrum,
+	preferred ...Product,
+) (map[string]string, *errors.PingenError) {
+	deliveryProducts := map[string]string{}
+
+	for _, country := range countries {
+		country = strings.ToUpper(country)
+		if _, done := deliveryProducts[country]; done {
+			continue
+		}
+
+		for _, product := range preferred {
+			if c.Validate(product, country, printMode, printSpectrum) == nil {
+				deliveryProducts[country] = string(product)
+				break
+			}
+		}
+
+		if _, found := deliveryProducts[country]; !found {
+			return nil, newValidationError(fmt.Sprintf("No preferred delivery product is available for %s", country), "", country)
+		}
+	}
+
+	return deliveryProducts, nil
+}
+
+// ValidateDeliveryProducts checks every country and product of a batch delivery_products map.
+func (c *Catalog) ValidateDeliveryProducts(
+	deliveryProducts map[string]string,
+	printMode PrintMode,
+	printSpectrum PrintSpectrum,
+) *errors.PingenError {
+	countries := make([]string, 0, len(deliveryProducts))
+	for country := range deliveryProducts {
+		countries = append(countries, country)
+	}
+	sort.Strings(countries)
+
+	for _, country := range countries {
+		if err := c.Validate(Product(deliveryProducts[country]), country, printMode, printSpectrum); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newValidationError(message string, product Product, country string) *errors.PingenError {
+	body, _ := json.Marshal(map[string]string{
+		"delivery_product": string(product),
+		"country":          strings.ToUpper(country),
+	})
+
+	return errors.NewPingenError(message, string(body), http.StatusUnprocessableEntity, nil)
+}
@@ -0,0 +1,107 @@
+package deliveryproducts_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/deliveryproducts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultCatalog_Products(t *testing.T) {
+	catalog := deliveryproducts.DefaultCatalog()
+
+	assert.Equal(t, []deliveryproducts.Product{
+		deliveryproducts.Fast,
+		deliveryproducts.Cheap,
+		deliveryproducts.DPAGStandard,
+		deliveryproducts.DPAGEconomy,
+	}, catalog.Products("de"))
+
+	assert.Contains(t, catalog.Products("CH"), deliveryproducts.PostAGA)
+	assert.Equal(t, []deliveryproducts.Product{deliveryproducts.Fast, deliveryproducts.Cheap}, catalog.Products("US"))
+}
+
+func TestDefaultCatalog_Validate(t *testing.T) {
+	catalog := deliveryproducts.DefaultCatalog()
+
+	assert.Nil(t, catalog.Validate(deliveryproducts.PostAGA, "LI", deliveryproducts.PrintModeDuplex, deliveryproducts.PrintSpectrumColor))
+	assert.Nil(t, catalog.Validate(deliveryproducts.IndpostMail, "CH", deliveryproducts.PrintModeSimplex, deliveryproducts.PrintSpectrumGrayscale))
+
+	err := catalog.Validate(deliveryproducts.IndpostSpeedmail, "LI", "", "")
+	assert.NotNil(t, err)
+	assert.Equal(t, `Delivery product "indpost_speedmail" is not available for LI`, err.Message)
+
+	err = catalog.Validate(deliveryproducts.Cheap, "US", "triplex", "")
+	assert.NotNil(t, err)
+	assert.Equal(t, `Delivery product "cheap" does not support print mode "triplex"`, err.Message)
+
+	err = catalog.Validate(deliveryproducts.DPAGStandard, "DE", "", "sepia")
+	assert.NotNil(t, err)
+	assert.Equal(t, `Delivery product "dpag_standard" does not support print spectrum "sepia"`, err.Message)
+}
+
+func TestCatalog_Validate(t *testing.T) {
+	catalog := deliveryproducts.NewCatalog(
+		deliveryproducts.Rule{Product: deliveryproducts.Fast},
+		deliveryproducts.Rule{
+			Product:        deliveryproducts.Bulk,
+			Countries:      []string{"CH"},
+			PrintModes:     []deliveryproducts.PrintMode{deliveryproducts.PrintModeDuplex},
+			PrintSpectrums: []deliveryproducts.PrintSpectrum{deliveryproducts.PrintSpectrumGrayscale},
+		},
+	)
+
+	assert.Nil(t, catalog.Validate(deliveryproducts.Fast, "DE", deliveryproducts.PrintModeSimplex, deliveryproducts.PrintSpectrumColor))
+	assert.Nil(t, catalog.Validate(deliveryproducts.Bulk, "ch", deliveryproducts.PrintModeDuplex, deliveryproducts.PrintSpectrumGrayscale))
+	assert.Nil(t, catalog.Validate(deliveryproducts.Fast, "", "", ""))
+
+	err := catalog.Validate("teleport", "CH", "", "")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, `Unknown delivery product "teleport"`, err.Message)
+
+	err = catalog.Validate(deliveryproducts.Bulk, "", "", "")
+	assert.NotNil(t, err)
+	assert.Equal(t, `Delivery product "bulk" cannot be checked without a country`, err.Message)
+
+	err = catalog.Validate(deliveryproducts.Bulk, "de", "", "")
+	assert.Equal(t, `Delivery product "bulk" is not available for DE`, err.Message)
+	assert.Equal(t, map[string]interface{}{"delivery_product": "bulk", "country": "DE"}, err.JSONBody)
+
+	err = catalog.Validate(deliveryproducts.Bulk, "CH", deliveryproducts.PrintModeSimplex, "")
+	assert.Equal(t, `Delivery product "bulk" does not support print mode "simplex"`, err.Message)
+
+	err = catalog.Validate(deliveryproducts.Bulk, "CH", "", deliveryproducts.PrintSpectrumColor)
+	assert.Equal(t, `Delivery product "bulk" does not support print spectrum "color"`, err.Message)
+}
+
+func TestCatalog_DeliveryProducts(t *testing.T) {
+	catalog := deliveryproducts.DefaultCatalog()
+
+	deliveryProducts, err := catalog.DeliveryProducts(
+		[]string{"ch", "DE", "US", "CH"},
+		deliveryproducts.PrintModeSimplex,
+		deliveryproducts.PrintSpectrumGrayscale,
+		deliveryproducts.PostAGB,
+		deliveryproducts.DPAGEconomy,
+		deliveryproducts.Cheap,
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"CH": "postag_b", "DE": "dpag_economy", "US": "cheap"}, deliveryProducts)
+
+	_, err = catalog.DeliveryProducts([]string{"CH", "FR"}, "", "", deliveryproducts.PostAGB)
+	assert.NotNil(t, err)
+	assert.Equal(t, "No preferred delivery product is available for FR", err.Message)
+}
+
+func TestCatalog_ValidateDeliveryProducts(t *testing.T) {
+	catalog := deliveryproducts.DefaultCatalog()
+
+	assert.Nil(t, catalog.ValidateDeliveryProducts(map[string]string{"CH": "postag_a", "DE": "fast"}, "", ""))
+
+	err := catalog.ValidateDeliveryProducts(map[string]string{"CH": "postag_a", "DE": "postag_a"}, "", "")
+	assert.NotNil(t, err)
+	assert.Equal(t, `Delivery product "postag_a" is not available for DE`, err.Message)
+}
@@ -10,8 +10,9 @@ import (
 )
 
 type Ebills struct {
-	organisationID string
-	apiRequestor   *api.APIRequestor
+	organisationID      string
+	apiRequestor        *api.APIRequestor
+	validateTransitions bool
 }
 
 type EbillResponse struct {
@@ -62,7 +63,9 @@ type EbillResponse struct {
 		Meta struct {
 			Abilities struct {
 				Self struct {
+					Cancel string `json:"cancel"`
 					Delete string `json:"delete"`
+					Submit string `json:"submit"`
 				} `json:"self"`
 			} `json:"abilities"`
 		} `json:"meta"`
@@ -136,8 +139,9 @@ type EbillCollectionResponse struct {
 
 func NewEbills(organisationID string, apiRequestor *api.APIRequestor) *Ebills {
 	return &Ebills{
-		organisationID: organisationID,
-		apiRequestor:   apiRequestor,
+		organisationID:      organisationID,
+		apiRequestor:        apiRequestor,
+		validateTransitions: true,
 	}
 }
 
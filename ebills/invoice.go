@@ -0,0 +1,148 @@
+package ebills
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+// DateFormat is the layout of invoice dates in the API.
+const DateFormat = "2006-01-02"
+
+// Currencies accepted for eBill invoices.
+var Currencies = map[string]bool{
+	"CHF": true,
+	"EUR": true,
+}
+
+var (
+	// eBill participant numbers are 17 digits and start with 41.
+	participantNumber = regexp.MustCompile(`^41\d{15}$`)
+	// Swiss enterprise identification number, e.g. CHE-123.456.789.
+	enterpriseNumber = regexp.MustCompile(`^CHE-?\d{3}\.?\d{3}\.?\d{3}$`)
+	emailAddress     = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+)
+
+// Invoice is the metadata an ebill is delivered with. RecipientIdentifier is the
+// recipient's eBill participant number, enterprise number (UID) or e-mail address.
+type Invoice struct {
+	RecipientIdentifier string
+	InvoiceNumber       string
+	InvoiceDate         time.Time
+	InvoiceDueDate      time.Time
+	InvoiceValue        float64
+	InvoiceCurrency     string
+}
+
+// Validate returns a 422 listing every invalid field by its API name, or nil.
+func (i Invoice) Validate() *errors.PingenError {
+	problems := map[string]string{}
+
+	switch identifier := strings.TrimSpace(i.RecipientIdentifier); {
+	case identifier == "":
+		problems["recipient_identifier"] = "is required"
+	case !participantNumber.MatchString(identifier) &&
+		!enterpriseNumber.MatchString(strings.ToUpper(identifier)) &&
+		!emailAddress.MatchString(identifier):
+		problems["recipient_identifier"] = "must be an eBill participant number, enterprise number or e-mail address"
+	}
+
+	if strings.TrimSpace(i.InvoiceNumber) == "" {
+		problems["invoice_number"] = "is required"
+	}
+
+	if i.InvoiceDate.IsZero() {
+		problems["invoice_date"] = "is required"
+	}
+
+	switch {
+	case i.InvoiceDueDate.IsZero():
+		problems["invoice_due_date"] = "is required"
+	case !i.InvoiceDate.IsZero() && dateOnly(i.InvoiceDueDate).Before(dateOnly(i.InvoiceDate)):
+		problems["invoice_due_date"] = "must not be before the invoice date"
+	}
+
+	switch cents := i.InvoiceValue * 100; {
+	case i.InvoiceValue <= 0:
+		problems["invoice_value"] = "must be greater than 0"
+	case math.Abs(cents-math.Round(cents)) > 1e-6:
+		problems["invoice_value"] = "must have at most 2 decimal places"
+	}
+
+	if !Currencies[strings.ToUpper(i.InvoiceCurrency)] {
+		problems["invoice_currency"] = "must be CHF or EUR"
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	body, _ := json.Marshal(problems)
+
+	return errors.NewPingenError("Invalid invoice", string(body), http.StatusUnprocessableEntity, nil)
+}
+
+// MetaData returns the invoice as the meta_data of a create request.
+func (i Invoice) MetaData() map[string]interface{} {
+	return map[string]interface{}{
+		"recipient_identifier": strings.TrimSpace(i.RecipientIdentifier),
+		"invoice_number":       i.InvoiceNumber,
+		"invoice_date":         i.InvoiceDate.Format(DateFormat),
+		"invoice_due_date":     i.InvoiceDueDate.Format(DateFormat),
+		"invoice_value":        math.Round(i.InvoiceValue*100) / 100,
+		"invoice_currency":     strings.ToUpper(i.InvoiceCurrency),
+	}
+}
+
+// UploadAndCreateInvoice is UploadAndCreate with typed invoice metadata, validated before the upload.
+func (e *Ebills) UploadAndCreateInvoice(
+	pathToFile, fileOriginalName string,
+	autoSend bool,
+	invoice Invoice,
+	relationships map[string]interface{},
+) (EbillResponse, *errors.PingenError) {
+	if err := invoice.Validate(); err != nil {
+		return EbillResponse{}, err
+	}
+
+	return e.UploadAndCreate(pathToFile, fileOriginalName, autoSend, invoice.MetaData(), relationships)
+}
+
+// CreateInvoice is Create with typed invoice metadata, validated before the request is made.
+func (e *Ebills) CreateInvoice(
+	fileURL, fileSignature, fileOriginalName string,
+	autoSend bool,
+	invoice Invoice,
+	relationships map[string]interface{},
+) (EbillResponse, *errors.PingenError) {
+	if err := invoice.Validate(); err != nil {
+		return EbillResponse{}, err
+	}
+
+	return e.Create(fileURL, fileSignature, fileOriginalName, autoSend, invoice.MetaData(), relationships)
+}
+
+// Invoice reads the invoice metadata back from the ebill. Dates the API left empty stay zero.
+func (r EbillResponse) Invoice() Invoice {
+	attributes := r.Data.Attributes
+	invoiceDate, _ := time.Parse(DateFormat, attributes.InvoiceDate)
+	dueDate, _ := time.Parse(DateFormat, attributes.InvoiceDueDate)
+
+	return Invoice{
+		RecipientIdentifier: attributes.RecipientIdentifier,
+		InvoiceNumber:       attributes.InvoiceNumber,
+		InvoiceDate:         invoiceDate,
+		InvoiceDueDate:      dueDate,
+		InvoiceValue:        attributes.InvoiceValue,
+		InvoiceCurrency:     attributes.InvoiceCurrency,
+	}
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
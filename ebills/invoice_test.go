@@ -0,0 +1,102 @@
+package ebills_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/ebills"
+	"github.com/stretchr/testify/assert"
+)
+
+func validInvoice() ebills.Invoice {
+	return ebills.Invoice{
+		RecipientIdentifier: "41100010014282213",
+		InvoiceNumber:       "Invoice 8051",
+		InvoiceDate:         time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		InvoiceDueDate:      time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC),
+		InvoiceValue:        1250.3,
+		InvoiceCurrency:     "chf",
+	}
+}
+
+func TestInvoice_Validate(t *testing.T) {
+	assert.Nil(t, validInvoice().Validate())
+
+	for _, identifier := range []string{"CHE-123.456.789", "che123456789", "billing@example.com"} {
+		invoice := validInvoice()
+		invoice.RecipientIdentifier = identifier
+		assert.Nil(t, invoice.Validate(), identifier)
+	}
+
+	invoice := ebills.Invoice{
+		RecipientIdentifier: "12345",
+		InvoiceDate:         time.Date(2025, 10, 30, 0, 0, 0, 0, time.UTC),
+		InvoiceDueDate:      time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+		InvoiceValue:        10.005,
+		InvoiceCurrency:     "USD",
+	}
+
+	err := invoice.Validate()
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, map[string]interface{}{
+		"recipient_identifier": "must be an eBill participant number, enterprise number or e-mail address",
+		"invoice_number":       "is required",
+		"invoice_due_date":     "must not be before the invoice date",
+		"invoice_value":        "must have at most 2 decimal places",
+		"invoice_currency":     "must be CHF or EUR",
+	}, err.JSONBody)
+
+	err = ebills.Invoice{}.Validate()
+	assert.Equal(t, "is required", err.JSONBody.(map[string]interface{})["invoice_date"])
+	assert.Equal(t, "must be greater than 0", err.JSONBody.(map[string]interface{})["invoice_value"])
+}
+
+func TestCreateInvoice(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var payload struct {
+			Data struct {
+				Attributes struct {
+					MetaData map[string]interface{} `json:"meta_data"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		assert.Nil(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, map[string]interface{}{
+			"recipient_identifier": "41100010014282213",
+			"invoice_number":       "Invoice 8051",
+			"invoice_date":         "2025-10-01",
+			"invoice_due_date":     "2025-10-30",
+			"invoice_value":        1250.3,
+			"invoice_currency":     "CHF",
+		}, payload.Data.Attributes.MetaData)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	ebillClient := setupEbill(server.URL)
+
+	resp, err := ebillClient.CreateInvoice("https://upload.example.com/file", "signature", "lorem.pdf", false, validInvoice(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, validInvoice().InvoiceDueDate, resp.Invoice().InvoiceDueDate)
+	assert.Equal(t, "CHF", resp.Invoice().InvoiceCurrency)
+
+	invalid := validInvoice()
+	invalid.InvoiceCurrency = "GBP"
+	_, err = ebillClient.CreateInvoice("https://upload.example.com/file", "signature", "lorem.pdf", false, invalid, nil)
+	assert.NotNil(t, err)
+
+	_, err = ebillClient.UploadAndCreateInvoice("testFile.pdf", "lorem.pdf", false, invalid, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,133 @@
+package ebills
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/response"
+)
+
+type Ability string
+
+const (
+	AbilitySubmit Ability = "submit"
+	AbilityCancel Ability = "cancel"
+	AbilityDelete Ability = "delete"
+)
+
+const pdfContentType = "application/pdf"
+
+func (r EbillResponse) AbilityValue(ability Ability) string {
+	self := r.Data.Meta.Abilities.Self
+
+	switch ability {
+	case AbilitySubmit:
+		return self.Submit
+	case AbilityCancel:
+		return self.Cancel
+	case AbilityDelete:
+		return self.Delete
+	default:
+		return ""
+	}
+}
+
+// Can reports whether the ebill grants the ability. Abilities the API does not report are assumed granted.
+func (r EbillResponse) Can(ability Ability) bool {
+	value := r.AbilityValue(ability)
+	return value == "" || value == response.AbilityOK
+}
+
+func (e *Ebills) SetTransitionValidation(enabled bool) {
+	e.validateTransitions = enabled
+}
+
+func (e *Ebills) Send(ebillID string) (EbillResponse, *errors.PingenError) {
+	if err := e.checkAbility(ebillID, AbilitySubmit); err != nil {
+		return EbillResponse{}, err
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   ebillID,
+			"type": "ebills",
+		},
+	})
+	url := fmt.Sprintf("/organisations/%s/deliveries/ebills/%s/send", e.organisationID, ebillID)
+
+	var response EbillResponse
+
+	_, err := e.apiRequestor.PerformPatchRequest(url, &response, payload, nil)
+	if err != nil {
+		return EbillResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (e *Ebills) Cancel(ebillID string) (interface{}, *errors.PingenError) {
+	if err := e.checkAbility(ebillID, AbilityCancel); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/organisations/%s/deliveries/ebills/%s/cancel", e.organisationID, ebillID)
+	return e.apiRequestor.PerformCancelRequest(url)
+}
+
+func (e *Ebills) Delete(ebillID string) (interface{}, *errors.PingenError) {
+	if err := e.checkAbility(ebillID, AbilityDelete); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/organisations/%s/deliveries/ebills/%s", e.organisationID, ebillID)
+	return e.apiRequestor.PerformDeleteRequest(url)
+}
+
+func (e *Ebills) GetFile(ebillID string) (io.ReadCloser, *errors.PingenError) {
+	return e.apiRequestor.PerformStreamRequest(e.fileURL(ebillID))
+}
+
+func (e *Ebills) Download(ebillID string, w io.Writer) (api.DownloadResult, *errors.PingenError) {
+	return e.apiRequestor.PerformDownloadRequest(e.fileURL(ebillID), w, pdfContentType)
+}
+
+func (e *Ebills) DownloadToFile(ebillID, path string) (api.DownloadResult, *errors.PingenError) {
+	return e.apiRequestor.PerformDownloadToFile(e.fileURL(ebillID), path, pdfContentType)
+}
+
+func (e *Ebills) fileURL(ebillID string) string {
+	return fmt.Sprintf("/organisations/%s/deliveries/ebills/%s/file", e.organisationID, ebillID)
+}
+
+func (e *Ebills) checkAbility(ebillID string, ability Ability) *errors.PingenError {
+	if !e.validateTransitions || api.IsDryRunID(ebillID) {
+		return nil
+	}
+
+	ebill, err := e.GetDetails(ebillID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if ebill.Can(ability) {
+		return nil
+	}
+
+	status := ebill.Data.Attributes.Status
+	body, _ := json.Marshal(map[string]string{
+		"ebill_id": ebillID,
+		"status":   status,
+		"action":   string(ability),
+	})
+
+	return errors.NewPingenError(
+		fmt.Sprintf("Ebill cannot %s in status %q", ability, status),
+		string(body),
+		http.StatusConflict,
+		nil,
+	)
+}
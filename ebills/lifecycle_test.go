@@ -0,0 +1,148 @@
+package ebills_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ebillPath = "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111/deliveries/ebills/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111"
+
+func TestSend(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"data": {"id": "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111", "type": "ebills"}}`, string(body))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	resp, err := setupEbill(server.URL).Send("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111", resp.Data.ID)
+	assert.Equal(t, []string{"GET " + ebillPath, "PATCH " + ebillPath + "/send"}, requests)
+}
+
+func TestCancel(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(mockResponse))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, err := setupEbill(server.URL).Cancel("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"GET " + ebillPath, "PATCH " + ebillPath + "/cancel"}, requests)
+}
+
+func TestDelete(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(mockResponse))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ebillClient := setupEbill(server.URL)
+
+	_, err := ebillClient.Delete("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, `Ebill cannot delete in status "send"`, err.Message)
+	assert.Equal(t, []string{"GET " + ebillPath}, requests)
+
+	requests = nil
+	ebillClient.SetTransitionValidation(false)
+
+	_, err = ebillClient.Delete("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"DELETE " + ebillPath}, requests)
+}
+
+func TestDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, ebillPath+"/file", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, "mock file content")
+	}))
+	defer server.Close()
+
+	ebillClient := setupEbill(server.URL)
+
+	var buf bytes.Buffer
+	result, err := ebillClient.Download("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111", &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, "mock file content", buf.String())
+	assert.Equal(t, int64(17), result.Size)
+
+	path := filepath.Join(t.TempDir(), "ebill.pdf")
+	_, err = ebillClient.DownloadToFile("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111", path)
+	assert.Nil(t, err)
+	content, _ := os.ReadFile(path)
+	assert.Equal(t, "mock file content", string(content))
+
+	stream, err := ebillClient.GetFile("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111")
+	assert.Nil(t, err)
+	content, _ = io.ReadAll(stream)
+	stream.Close()
+	assert.Equal(t, "mock file content", string(content))
+}
+
+func TestWaitUntilValidated(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.WriteHeader(http.StatusOK)
+		if polls < 3 {
+			_, _ = w.Write([]byte(strings.Replace(mockResponse, `"status": "send"`, `"status": "validating"`, 1)))
+			return
+		}
+		_, _ = w.Write([]byte(strings.Replace(mockResponse, `"status": "send"`, `"status": "valid"`, 1)))
+	}))
+	defer server.Close()
+
+	ebillClient := setupEbill(server.URL)
+
+	_, err := ebillClient.WaitUntilValidated("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111", time.Millisecond, 0)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusRequestTimeout, err.StatusCode)
+
+	ebill, err := ebillClient.WaitUntilValidated("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxx111", time.Millisecond, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "valid", ebill.Data.Attributes.Status)
+	assert.Equal(t, 3, polls)
+}
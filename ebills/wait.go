@@ -0,0 +1,38 @@
+package ebills
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+const statusValidating = "validating"
+
+// WaitUntilValidated polls the ebill until Pingen has finished validating it, so it can be sent.
+func (e *Ebills) WaitUntilValidated(ebillID string, pollInterval, timeout time.Duration) (EbillResponse, *errors.PingenError) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		ebill, err := e.GetDetails(ebillID, nil, nil)
+		if err != nil {
+			return EbillResponse{}, err
+		}
+
+		if ebill.Data.Attributes.Status != statusValidating {
+			return ebill, nil
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return ebill, errors.NewPingenError(
+				fmt.Sprintf("Ebill still validating after %s", timeout),
+				"",
+				http.StatusRequestTimeout,
+				nil,
+			)
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
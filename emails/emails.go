@@ -10,8 +10,9 @@ import (
 )
 
 type Emails struct {
-	organisationID string
-	apiRequestor   *api.APIRequestor
+	organisationID      string
+	apiRequestor        *api.APIRequestor
+	validateTransitions bool
 }
 
 type EmailResponse struct {
@@ -57,7 +58,9 @@ type EmailResponse struct {
 		Meta struct {
 			Abilities struct {
 				Self struct {
+					Cancel string `json:"cancel"`
 					Delete string `json:"delete"`
+					Submit string `json:"submit"`
 				} `json:"self"`
 			} `json:"abilities"`
 		} `json:"meta"`
@@ -126,8 +129,9 @@ type EmailCollectionResponse struct {
 
 func NewEmails(organisationID string, apiRequestor *api.APIRequestor) *Emails {
 	return &Emails{
-		organisationID: organisationID,
-		apiRequestor:   apiRequestor,
+		organisationID:      organisationID,
+		apiRequestor:        apiRequestor,
+		validateTransitions: true,
 	}
 }
 
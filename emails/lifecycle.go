@@ -0,0 +1,133 @@
+package emails
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/response"
+)
+
+type Ability string
+
+const (
+	AbilitySubmit Ability = "submit"
+	AbilityCancel Ability = "cancel"
+	AbilityDelete Ability = "delete"
+)
+
+const pdfContentType = "application/pdf"
+
+func (r EmailResponse) AbilityValue(ability Ability) string {
+	self := r.Data.Meta.Abilities.Self
+
+	switch ability {
+	case AbilitySubmit:
+		return self.Submit
+	case AbilityCancel:
+		return self.Cancel
+	case AbilityDelete:
+		return self.Delete
+	default:
+		return ""
+	}
+}
+
+// Can reports whether the email grants the ability. Abilities the API does not report are assumed granted.
+func (r EmailResponse) Can(ability Ability) bool {
+	value := r.AbilityValue(ability)
+	return value == "" || value == response.AbilityOK
+}
+
+func (e *Emails) SetTransitionValidation(enabled bool) {
+	e.validateTransitions = enabled
+}
+
+func (e *Emails) Send(emailID string) (EmailResponse, *errors.PingenError) {
+	if err := e.checkAbility(emailID, AbilitySubmit); err != nil {
+		return EmailResponse{}, err
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":   emailID,
+			"type": "emails",
+		},
+	})
+	url := fmt.Sprintf("/organisations/%s/deliveries/emails/%s/send", e.organisationID, emailID)
+
+	var response EmailResponse
+
+	_, err := e.apiRequestor.PerformPatchRequest(url, &response, payload, nil)
+	if err != nil {
+		return EmailResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (e *Emails) Cancel(emailID string) (interface{}, *errors.PingenError) {
+	if err := e.checkAbility(emailID, AbilityCancel); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/organisations/%s/deliveries/emails/%s/cancel", e.organisationID, emailID)
+	return e.apiRequestor.PerformCancelRequest(url)
+}
+
+func (e *Emails) Delete(emailID string) (interface{}, *errors.PingenError) {
+	if err := e.checkAbility(emailID, AbilityDelete); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("/organisations/%s/deliveries/emails/%s", e.organisationID, emailID)
+	return e.apiRequestor.PerformDeleteRequest(url)
+}
+
+func (e *Emails) GetFile(emailID string) (io.ReadCloser, *errors.PingenError) {
+	return e.apiRequestor.PerformStreamRequest(e.fileURL(emailID))
+}
+
+func (e *Emails) Download(emailID string, w io.Writer) (api.DownloadResult, *errors.PingenError) {
+	return e.apiRequestor.PerformDownloadRequest(e.fileURL(emailID), w, pdfContentType)
+}
+
+func (e *Emails) DownloadToFile(emailID, path string) (api.DownloadResult, *errors.PingenError) {
+	return e.apiRequestor.PerformDownloadToFile(e.fileURL(emailID), path, pdfContentType)
+}
+
+func (e *Emails) fileURL(emailID string) string {
+	return fmt.Sprintf("/organisations/%s/deliveries/emails/%s/file", e.organisationID, emailID)
+}
+
+func (e *Emails) checkAbility(emailID string, ability Ability) *errors.PingenError {
+	if !e.validateTransitions || api.IsDryRunID(emailID) {
+		return nil
+	}
+
+	email, err := e.GetDetails(emailID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if email.Can(ability) {
+		return nil
+	}
+
+	status := email.Data.Attributes.Status
+	body, _ := json.Marshal(map[string]string{
+		"email_id": emailID,
+		"status":   status,
+		"action":   string(ability),
+	})
+
+	return errors.NewPingenError(
+		fmt.Sprintf("Email cannot %s in status %q", ability, status),
+		string(body),
+		http.StatusConflict,
+		nil,
+	)
+}
@@ -0,0 +1,148 @@
+package emails_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const emailPath = "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11/deliveries/emails/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11"
+
+func TestSend(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodPatch {
+			body, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"data": {"id": "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11", "type": "emails"}}`, string(body))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	resp, err := setupEmail(server.URL).Send("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11", resp.Data.ID)
+	assert.Equal(t, []string{"GET " + emailPath, "PATCH " + emailPath + "/send"}, requests)
+}
+
+func TestCancel(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(mockResponse))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, err := setupEmail(server.URL).Cancel("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"GET " + emailPath, "PATCH " + emailPath + "/cancel"}, requests)
+}
+
+func TestDelete(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(mockResponse))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	emailClient := setupEmail(server.URL)
+
+	_, err := emailClient.Delete("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, `Email cannot delete in status "send"`, err.Message)
+	assert.Equal(t, []string{"GET " + emailPath}, requests)
+
+	requests = nil
+	emailClient.SetTransitionValidation(false)
+
+	_, err = emailClient.Delete("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"DELETE " + emailPath}, requests)
+}
+
+func TestDownload(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, emailPath+"/file", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, "mock file content")
+	}))
+	defer server.Close()
+
+	emailClient := setupEmail(server.URL)
+
+	var buf bytes.Buffer
+	result, err := emailClient.Download("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11", &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, "mock file content", buf.String())
+	assert.Equal(t, int64(17), result.Size)
+
+	path := filepath.Join(t.TempDir(), "email.pdf")
+	_, err = emailClient.DownloadToFile("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11", path)
+	assert.Nil(t, err)
+	content, _ := os.ReadFile(path)
+	assert.Equal(t, "mock file content", string(content))
+
+	stream, err := emailClient.GetFile("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11")
+	assert.Nil(t, err)
+	content, _ = io.ReadAll(stream)
+	stream.Close()
+	assert.Equal(t, "mock file content", string(content))
+}
+
+func TestWaitUntilValidated(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.WriteHeader(http.StatusOK)
+		if polls < 3 {
+			_, _ = w.Write([]byte(strings.Replace(mockResponse, `"status": "send"`, `"status": "validating"`, 1)))
+			return
+		}
+		_, _ = w.Write([]byte(strings.Replace(mockResponse, `"status": "send"`, `"status": "valid"`, 1)))
+	}))
+	defer server.Close()
+
+	emailClient := setupEmail(server.URL)
+
+	_, err := emailClient.WaitUntilValidated("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11", time.Millisecond, 0)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusRequestTimeout, err.StatusCode)
+
+	email, err := emailClient.WaitUntilValidated("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxx11", time.Millisecond, time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "valid", email.Data.Attributes.Status)
+	assert.Equal(t, 3, polls)
+}
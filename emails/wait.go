@@ -0,0 +1,38 @@
+package emails
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+const statusValidating = "validating"
+
+// WaitUntilValidated polls the email until Pingen has finished validating it, so it can be sent.
+func (e *Emails) WaitUntilValidated(emailID string, pollInterval, timeout time.Duration) (EmailResponse, *errors.PingenError) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		email, err := e.GetDetails(emailID, nil, nil)
+		if err != nil {
+			return EmailResponse{}, err
+		}
+
+		if email.Data.Attributes.Status != statusValidating {
+			return email, nil
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return email, errors.NewPingenError(
+				fmt.Sprintf("Email still validating after %s", timeout),
+				"",
+				http.StatusRequestTimeout,
+				nil,
+			)
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
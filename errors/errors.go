@@ -60,3 +60,55 @@ func NewWebhookSignatureException(message string) *WebhookSignatureException {
 func (e *WebhookSignatureException) Error() string {
 	return fmt.Sprintf("WebhookSignatureException: %s", e.Message)
 }
+
+// BudgetExceededError reports a send that was blocked because its cost would exceed a spending limit.
+type BudgetExceededError struct {
+	PingenError
+	Limit    string
+	Allowed  float64
+	Spent    float64
+	Cost     float64
+	Currency string
+}
+
+func NewBudgetExceededError(limit string, allowed, spent, cost float64, currency string) *BudgetExceededError {
+	body, _ := json.Marshal(map[string]interface{}{
+		"limit":    limit,
+		"allowed":  allowed,
+		"spent":    spent,
+		"cost":     cost,
+		"currency": currency,
+	})
+
+	baseError := NewPingenError(
+		fmt.Sprintf("Budget exceeded: cost of %.2f %s exceeds %s limit of %.2f (already spent %.2f)", cost, currency, limit, allowed, spent),
+		string(body),
+		402,
+		nil,
+	)
+
+	return &BudgetExceededError{
+		PingenError: *baseError,
+		Limit:       limit,
+		Allowed:     allowed,
+		Spent:       spent,
+		Cost:        cost,
+		Currency:    currency,
+	}
+}
+
+// IsRejection reports whether the API definitively refused a request: a 4xx other than
+// 408 or 429. After any other error the request may still have been applied, or repeating
+// it may succeed.
+func IsRejection(err *PingenError) bool {
+	if err == nil {
+		return false
+	}
+
+	switch err.StatusCode {
+	case 408, 429:
+		return false
+	default:
+		return err.StatusCode >= 400 && err.StatusCode < 500
+	}
+}
@@ -198,3 +198,49 @@ func TestWebhookSignatureException_Error(t *testing.T) {
 		}
 	})
 }
+
+func TestNewBudgetExceededError(t *testing.T) {
+	t.Run("budget exceeded error creation", func(t *testing.T) {
+		err := NewBudgetExceededError("per_day", 100, 95.5, 12.25, "CHF")
+
+		if err.StatusCode != 402 {
+			t.Errorf("Expected status code 402, got %d", err.StatusCode)
+		}
+		if err.Limit != "per_day" || err.Allowed != 100 || err.Spent != 95.5 || err.Cost != 12.25 || err.Currency != "CHF" {
+			t.Errorf("Unexpected budget details %+v", err)
+		}
+
+		bodyMap, ok := err.JSONBody.(map[string]interface{})
+		if !ok || bodyMap["limit"] != "per_day" {
+			t.Errorf("Expected JSONBody with limit, got %v", err.JSONBody)
+		}
+
+		expected := "PingenError: Budget exceeded: cost of 12.25 CHF exceeds per_day limit of 100.00 (already spent 95.50) (Status Code: 402, Request ID: )"
+		if err.Error() != expected {
+			t.Errorf("Expected error string '%s', got '%s'", expected, err.Error())
+		}
+	})
+}
+
+func TestIsRejection(t *testing.T) {
+	cases := map[int]bool{
+		400: true,
+		404: true,
+		409: true,
+		422: true,
+		408: false,
+		429: false,
+		500: false,
+		503: false,
+	}
+
+	for statusCode, expected := range cases {
+		if got := IsRejection(NewPingenError("error", "", statusCode, nil)); got != expected {
+			t.Errorf("IsRejection for status %d: expected %v, got %v", statusCode, expected, got)
+		}
+	}
+
+	if IsRejection(nil) {
+		t.Errorf("Expected nil error not to be a rejection")
+	}
+}
@@ -0,0 +1,298 @@
+package incomingwebhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+type Category string
+
+const (
+	CategoryIssues        Category = "issues"
+	CategorySent          Category = "sent"
+	CategoryUndeliverable Category = "undeliverable"
+	CategoryDelivered     Category = "delivered"
+)
+
+type Organisation struct {
+	ID                     string  `json:"-"`
+	Name                   string  `json:"name"`
+	Status                 string  `json:"status"`
+	Plan                   string  `json:"plan"`
+	BillingMode            string  `json:"billing_mode"`
+	BillingCurrency        string  `json:"billing_currency"`
+	BillingBalance         float64 `json:"billing_balance"`
+	DefaultCountry         string  `json:"default_country"`
+	Edition                string  `json:"edition"`
+	DefaultAddressPosition string  `json:"default_address_position"`
+	DataRetentionAddresses int     `json:"data_retention_addresses"`
+	DataRetentionPDF       int     `json:"data_retention_pdf"`
+	Color                  string  `json:"color"`
+	CreatedAt              string  `json:"created_at"`
+	UpdatedAt              string  `json:"updated_at"`
+}
+
+type Letter struct {
+	ID               string   `json:"-"`
+	Status           string   `json:"status"`
+	FileOriginalName string   `json:"file_original_name"`
+	FilePages        int      `json:"file_pages"`
+	Address          string   `json:"address"`
+	AddressPosition  string   `json:"address_position"`
+	Country          string   `json:"country"`
+	DeliveryProduct  string   `json:"delivery_product"`
+	PrintMode        string   `json:"print_mode"`
+	PrintSpectrum    string   `json:"print_spectrum"`
+	PriceCurrency    string   `json:"price_currency"`
+	PriceValue       float64  `json:"price_value"`
+	PaperTypes       []string `json:"paper_types"`
+	Fonts            []struct {
+		Name       string `json:"name"`
+		IsEmbedded bool   `json:"is_embedded"`
+	} `json:"fonts"`
+	Source         string `json:"source"`
+	TrackingNumber string `json:"tracking_number"`
+	SubmittedAt    string `json:"submitted_at"`
+	CreatedAt      string `json:"created_at"`
+	UpdatedAt      string `json:"updated_at"`
+}
+
+type Batch struct {
+	ID               string  `json:"-"`
+	Name             string  `json:"name"`
+	Icon             string  `json:"icon"`
+	Status           string  `json:"status"`
+	FileOriginalName string  `json:"file_original_name"`
+	LetterCount      int     `json:"letter_count"`
+	AddressPosition  string  `json:"address_position"`
+	PrintMode        string  `json:"print_mode"`
+	PrintSpectrum    string  `json:"print_spectrum"`
+	PriceCurrency    string  `json:"price_currency"`
+	PriceValue       float64 `json:"price_value"`
+	Source           string  `json:"source"`
+	SubmittedAt      string  `json:"submitted_at"`
+	CreatedAt        string  `json:"created_at"`
+	UpdatedAt        string  `json:"updated_at"`
+}
+
+type Ebill struct {
+	ID                  string  `json:"-"`
+	Status              string  `json:"status"`
+	FileOriginalName    string  `json:"file_original_name"`
+	FilePages           int     `json:"file_pages"`
+	RecipientIdentifier string  `json:"recipient_identifier"`
+	InvoiceNumber       string  `json:"invoice_number"`
+	InvoiceDate         string  `json:"invoice_date"`
+	InvoiceDueDate      string  `json:"invoice_due_date"`
+	InvoiceValue        float64 `json:"invoice_value"`
+	InvoiceCurrency     string  `json:"invoice_currency"`
+	PriceCurrency       string  `json:"price_currency"`
+	PriceValue          float64 `json:"price_value"`
+	Source              string  `json:"source"`
+	SubmittedAt         string  `json:"submitted_at"`
+	CreatedAt           string  `json:"created_at"`
+	UpdatedAt           string  `json:"updated_at"`
+}
+
+type LetterEvent struct {
+	ID        string   `json:"-"`
+	Code      string   `json:"code"`
+	Name      string   `json:"name"`
+	Producer  string   `json:"producer"`
+	Location  string   `json:"location"`
+	HasImage  bool     `json:"has_image"`
+	Data      []string `json:"data"`
+	EmittedAt string   `json:"emitted_at"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// Event holds what every webhook event carries. The related resources are resolved from the
+// payload's included section and are nil when the payload does not include them; their IDs
+// are always set when the relationship is present.
+type Event struct {
+	ID        string
+	Type      string
+	Category  Category
+	Reason    string
+	URL       string
+	CreatedAt string
+
+	OrganisationID string
+	LetterID       string
+	EventID        string
+	BatchID        string
+	EbillID        string
+
+	Organisation *Organisation
+	Letter       *Letter
+	LetterEvent  *LetterEvent
+	Batch        *Batch
+	Ebill        *Ebill
+}
+
+// TypedEvent is one of *IssueEvent, *SentEvent, *UndeliverableEvent, *DeliveredEvent,
+// *BatchEvent, *EbillEvent or *UnknownEvent.
+type TypedEvent interface {
+	Common() *Event
+}
+
+type IssueEvent struct{ Event }
+type SentEvent struct{ Event }
+type UndeliverableEvent struct{ Event }
+type DeliveredEvent struct{ Event }
+
+// BatchEvent and EbillEvent are events about a batch or an ebill rather than a single letter,
+// whatever their category. The category is still available through Common.
+type BatchEvent struct{ Event }
+type EbillEvent struct{ Event }
+
+// UnknownEvent is returned for categories this package does not know yet, so receivers
+// can still acknowledge them.
+type UnknownEvent struct {
+	Event
+	Payload string
+}
+
+func (e *Event) Common() *Event { return e }
+
+type relationship struct {
+	Data *struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+	} `json:"data"`
+}
+
+func (r relationship) id() string {
+	if r.Data == nil {
+		return ""
+	}
+	return r.Data.ID
+}
+
+type resource struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Attributes json.RawMessage `json:"attributes"`
+}
+
+// decode fills target from the resource's attributes. A resource included without
+// attributes leaves target as it is.
+func (r resource) decode(target interface{}) error {
+	if len(r.Attributes) == 0 || string(r.Attributes) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(r.Attributes, target)
+}
+
+type document struct {
+	Data struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			Reason    string `json:"reason"`
+			URL       string `json:"url"`
+			CreatedAt string `json:"created_at"`
+		} `json:"attributes"`
+		Relationships struct {
+			Organisation relationship `json:"organisation"`
+			Letter       relationship `json:"letter"`
+			Event        relationship `json:"event"`
+			Batch        relationship `json:"batch"`
+			Ebill        relationship `json:"ebill"`
+		} `json:"relationships"`
+	} `json:"data"`
+	Included []resource `json:"included"`
+}
+
+// Parse decodes the payload into the typed event of its category.
+func (we *WebhookEvent) Parse() (TypedEvent, error) {
+	var doc document
+	if err := json.Unmarshal([]byte(we.Payload), &doc); err != nil {
+		return nil, newPayloadError(err.Error())
+	}
+
+	if doc.Data.Type == "" {
+		return nil, newPayloadError("data type is missing")
+	}
+
+	relationships := doc.Data.Relationships
+	event := Event{
+		ID:             doc.Data.ID,
+		Type:           doc.Data.Type,
+		Category:       Category(strings.TrimPrefix(doc.Data.Type, "webhook_")),
+		Reason:         doc.Data.Attributes.Reason,
+		URL:            doc.Data.Attributes.URL,
+		CreatedAt:      doc.Data.Attributes.CreatedAt,
+		OrganisationID: relationships.Organisation.id(),
+		LetterID:       relationships.Letter.id(),
+		EventID:        relationships.Event.id(),
+		BatchID:        relationships.Batch.id(),
+		EbillID:        relationships.Ebill.id(),
+	}
+
+	for _, included := range doc.Included {
+		var err error
+
+		switch {
+		case included.Type == "organisations" && included.ID == event.OrganisationID:
+			event.Organisation = &Organisation{ID: included.ID}
+			err = included.decode(event.Organisation)
+		case included.Type == "letters" && included.ID == event.LetterID:
+			event.Letter = &Letter{ID: included.ID}
+			err = included.decode(event.Letter)
+		case included.Type == "letters_events" && included.ID == event.EventID:
+			event.LetterEvent = &LetterEvent{ID: included.ID}
+			err = included.decode(event.LetterEvent)
+		case included.Type == "batches" && included.ID == event.BatchID:
+			event.Batch = &Batch{ID: included.ID}
+			err = included.decode(event.Batch)
+		case included.Type == "ebills" && included.ID == event.EbillID:
+			event.Ebill = &Ebill{ID: included.ID}
+			err = included.decode(event.Ebill)
+		}
+
+		if err != nil {
+			return nil, newPayloadError(fmt.Sprintf("included %s %s: %v", included.Type, included.ID, err))
+		}
+	}
+
+	switch {
+	case event.LetterID == "" && event.EbillID != "":
+		return &EbillEvent{event}, nil
+	case event.LetterID == "" && event.BatchID != "":
+		return &BatchEvent{event}, nil
+	}
+
+	switch event.Category {
+	case CategoryIssues:
+		return &IssueEvent{event}, nil
+	case CategorySent:
+		return &SentEvent{event}, nil
+	case CategoryUndeliverable:
+		return &UndeliverableEvent{event}, nil
+	case CategoryDelivered:
+		return &DeliveredEvent{event}, nil
+	default:
+		return &UnknownEvent{Event: event, Payload: we.Payload}, nil
+	}
+}
+
+// ConstructTypedEvent verifies the signature like ConstructEvent and parses the payload.
+func (iw *IncomingWebhook) ConstructTypedEvent(payload string, headers map[string]string, secret string) (TypedEvent, error) {
+	event, err := iw.ConstructEvent(payload, headers, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return event.Parse()
+}
+
+func newPayloadError(problem string) *errors.PingenError {
+	return errors.NewPingenError("Invalid webhook payload: "+problem, "", http.StatusBadRequest, nil)
+}
@@ -0,0 +1,134 @@
+package incomingwebhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/incomingwebhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const issuePayload = `{"data":{"type":"webhook_issues","id":"a3233e48-5e70-4138-95b2-a72d4875016b","attributes":{"reason":"Page limit exceeded","url":"https:\/\/test\/receiver","created_at":"2023-08-03T11:24:39+0200"},"relationships":{"organisation":{"links":{"related":"http:\/\/api-test.v2.pingen.com\/organisations\/2017973a-6403-444d-af05-eb4b2b7f5e2f"},"data":{"type":"organisations","id":"2017973a-6403-444d-af05-eb4b2b7f5e2f"}},"letter":{"links":{"related":"http:\/\/api-test.v2.pingen.com\/organisations\/2017973a-6403-444d-af05-eb4b2b7f5e2f\/letters\/4f31cdb2-bc0d-4db5-a13d-3336958dba02"},"data":{"type":"letters","id":"4f31cdb2-bc0d-4db5-a13d-3336958dba02"}},"event":{"data":{"type":"letters_events","id":"ba08eb5f-413c-4dd1-8ed6-aac2b96124d0"}}}},"included":[{"type":"organisations","id":"2017973a-6403-444d-af05-eb4b2b7f5e2f","attributes":{"name":"Prof. Leopoldo Hahn","status":"active","plan":"free","billing_mode":"postpaid","billing_currency":"CHF","billing_balance":0,"default_country":"CH","edition":"pingen","default_address_position":"left","data_retention_addresses":12,"data_retention_pdf":12,"color":"#0758FF","created_at":"2023-08-03T11:24:39+0200","updated_at":"2023-08-03T11:24:39+0200"},"links":{"self":"http:\/\/api-test.v2.pingen.com\/organisations\/2017973a-6403-444d-af05-eb4b2b7f5e2f"}},{"type":"letters","id":"4f31cdb2-bc0d-4db5-a13d-3336958dba02","attributes":{"status":"validating","file_original_name":"ullam.pdf","file_pages":null,"address":null,"address_position":"left","country":null,"delivery_product":"fast","print_mode":"simplex","print_spectrum":"color","price_currency":null,"price_value":null,"paper_types":null,"fonts":null,"source":"app","tracking_number":null,"submitted_at":null,"created_at":"2023-08-03T11:24:39+0200","updated_at":"2023-08-03T11:24:39+0200"},"links":{"self":"http:\/\/api-test.v2.pingen.com\/organisations\/2017973a-6403-444d-af05-eb4b2b7f5e2f\/letters\/4f31cdb2-bc0d-4db5-a13d-3336958dba02"}},{"type":"letters_events","id":"ba08eb5f-413c-4dd1-8ed6-aac2b96124d0","attributes":{"code":"file_too_many_pages","name":"Page limit exceeded","producer":"Pingen","location":"","has_image":false,"data":[],"emitted_at":"2023-08-03T11:24:39+0200","created_at":"2023-08-03T11:24:39+0200","updated_at":"2023-08-03T11:24:39+0200"}}]}`
+
+func sign(payload, secret string) map[string]string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(payload))
+
+	return map[string]string{"Signature": hex.EncodeToString(h.Sum(nil))}
+}
+
+func TestConstructTypedEvent_Issue(t *testing.T) {
+	webhook := incomingwebhook.IncomingWebhook{}
+
+	event, err := webhook.ConstructTypedEvent(issuePayload, sign(issuePayload, "secret"), "secret")
+	assert.Nil(t, err)
+
+	issue, ok := event.(*incomingwebhook.IssueEvent)
+	assert.True(t, ok)
+	assert.Equal(t, incomingwebhook.CategoryIssues, issue.Category)
+	assert.Equal(t, "Page limit exceeded", issue.Reason)
+	assert.Equal(t, "2017973a-6403-444d-af05-eb4b2b7f5e2f", issue.OrganisationID)
+	assert.Equal(t, "4f31cdb2-bc0d-4db5-a13d-3336958dba02", issue.LetterID)
+
+	assert.Equal(t, "Prof. Leopoldo Hahn", issue.Organisation.Name)
+	assert.Equal(t, "CHF", issue.Organisation.BillingCurrency)
+	assert.Equal(t, "4f31cdb2-bc0d-4db5-a13d-3336958dba02", issue.Letter.ID)
+	assert.Equal(t, "validating", issue.Letter.Status)
+	assert.Equal(t, "ullam.pdf", issue.Letter.FileOriginalName)
+	assert.Equal(t, "ba08eb5f-413c-4dd1-8ed6-aac2b96124d0", issue.LetterEvent.ID)
+	assert.Equal(t, "file_too_many_pages", issue.LetterEvent.Code)
+	assert.Same(t, &issue.Event, event.Common())
+}
+
+func TestParse_Categories(t *testing.T) {
+	tests := []struct {
+		dataType string
+		check    func(incomingwebhook.TypedEvent) bool
+	}{
+		{"webhook_sent", func(e incomingwebhook.TypedEvent) bool { _, ok := e.(*incomingwebhook.SentEvent); return ok }},
+		{"webhook_undeliverable", func(e incomingwebhook.TypedEvent) bool { _, ok := e.(*incomingwebhook.UndeliverableEvent); return ok }},
+		{"webhook_delivered", func(e incomingwebhook.TypedEvent) bool { _, ok := e.(*incomingwebhook.DeliveredEvent); return ok }},
+		{"webhook_something_new", func(e incomingwebhook.TypedEvent) bool { _, ok := e.(*incomingwebhook.UnknownEvent); return ok }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dataType, func(t *testing.T) {
+			payload := strings.Replace(issuePayload, `"webhook_issues"`, `"`+tt.dataType+`"`, 1)
+			payload = strings.Replace(payload, `"included":[`, `"included":[{"type":"letters","id":"someone-else","attributes":{"status":"sent"}},`, 1)
+
+			event, err := (&incomingwebhook.WebhookEvent{Payload: payload}).Parse()
+
+			assert.Nil(t, err)
+			assert.True(t, tt.check(event))
+			assert.Equal(t, "validating", event.Common().Letter.Status)
+		})
+	}
+}
+
+func TestParse_MissingIncluded(t *testing.T) {
+	payload := issuePayload[:strings.Index(issuePayload, `"included":`)] + `"included":[]}`
+
+	event, err := (&incomingwebhook.WebhookEvent{Payload: payload}).Parse()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "4f31cdb2-bc0d-4db5-a13d-3336958dba02", event.Common().LetterID)
+	assert.Nil(t, event.Common().Letter)
+	assert.Nil(t, event.Common().Organisation)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, payload := range []string{"not json", `{"data":{}}`} {
+		_, err := (&incomingwebhook.WebhookEvent{Payload: payload}).Parse()
+
+		assert.NotNil(t, err)
+		pingenErr, ok := err.(*errors.PingenError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, pingenErr.StatusCode)
+	}
+
+	webhook := incomingwebhook.IncomingWebhook{}
+	_, err := webhook.ConstructTypedEvent(issuePayload, sign(issuePayload, "other"), "secret")
+	_, ok := err.(*errors.WebhookSignatureException)
+	assert.True(t, ok)
+}
+
+func TestParse_BatchAndEbill(t *testing.T) {
+	batchPayload := `{"data":{"type":"webhook_sent","id":"event-1","attributes":{"reason":"","url":"https:\/\/test\/receiver","created_at":"2023-08-03T11:24:39+0200"},"relationships":{"organisation":{"data":{"type":"organisations","id":"org-1"}},"batch":{"data":{"type":"batches","id":"batch-1"}}}},"included":[{"type":"batches","id":"batch-1","attributes":{"name":"October invoices","status":"submitted","letter_count":12}}]}`
+
+	event, err := (&incomingwebhook.WebhookEvent{Payload: batchPayload}).Parse()
+
+	assert.Nil(t, err)
+	batch, ok := event.(*incomingwebhook.BatchEvent)
+	assert.True(t, ok)
+	assert.Equal(t, incomingwebhook.CategorySent, batch.Category)
+	assert.Equal(t, "batch-1", batch.BatchID)
+	assert.Equal(t, "October invoices", batch.Batch.Name)
+	assert.Equal(t, 12, batch.Batch.LetterCount)
+
+	ebillPayload := `{"data":{"type":"webhook_issues","id":"event-2","attributes":{"reason":"Recipient unknown","url":"https:\/\/test\/receiver","created_at":"2023-08-03T11:24:39+0200"},"relationships":{"ebill":{"data":{"type":"ebills","id":"ebill-1"}}}},"included":[{"type":"ebills","id":"ebill-1","attributes":{"status":"invalid","invoice_number":"8051"}}]}`
+
+	event, err = (&incomingwebhook.WebhookEvent{Payload: ebillPayload}).Parse()
+
+	assert.Nil(t, err)
+	ebill, ok := event.(*incomingwebhook.EbillEvent)
+	assert.True(t, ok)
+	assert.Equal(t, incomingwebhook.CategoryIssues, ebill.Category)
+	assert.Equal(t, "ebill-1", ebill.Ebill.ID)
+	assert.Equal(t, "8051", ebill.Ebill.InvoiceNumber)
+}
+
+func TestParse_IncludedWithoutAttributes(t *testing.T) {
+	payload := issuePayload[:strings.Index(issuePayload, `"included":`)] +
+		`"included":[{"type":"letters","id":"4f31cdb2-bc0d-4db5-a13d-3336958dba02"},{"type":"organisations","id":"2017973a-6403-444d-af05-eb4b2b7f5e2f","attributes":null}]}`
+
+	event, err := (&incomingwebhook.WebhookEvent{Payload: payload}).Parse()
+
+	assert.Nil(t, err)
+	assert.Equal(t, &incomingwebhook.Letter{ID: "4f31cdb2-bc0d-4db5-a13d-3336958dba02"}, event.Common().Letter)
+	assert.Equal(t, "2017973a-6403-444d-af05-eb4b2b7f5e2f", event.Common().Organisation.ID)
+}
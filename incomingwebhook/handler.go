@@ -0,0 +1,138 @@
+package incomingwebhook
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+// DefaultMaxBodyBytes is the largest webhook body Handler accepts unless configured otherwise.
+const DefaultMaxBodyBytes = 1 << 20
+
+// HandlerFunc handles one webhook event. Returning an error makes Pingen deliver the event
+// again, unless the error is wrapped with Permanent.
+type HandlerFunc func(ctx context.Context, event TypedEvent) error
+
+// Handler is an http.Handler receiving Pingen webhooks. It verifies the signature, decodes
+// the event and calls the function registered for its category. Responses tell Pingen
+// whether to retry:
+//
+//   - 200 when the event was handled or no function is registered for its category
+//   - 401 for a missing or wrong signature, 400 for a malformed payload
+//   - 413 when the body exceeds the size limit, 405 for anything but POST
+//   - 422 for errors wrapped with Permanent, and 500 for any other handler error, so the
+//     delivery is retried. Status codes of API errors the handler ran into are not passed
+//     on, since they describe Pingen's answer to the handler, not the webhook delivery.
+type Handler struct {
+	secret       string
+	maxBodyBytes int64
+	handlers     map[Category]HandlerFunc
+	fallback     HandlerFunc
+}
+
+func NewHandler(secret string) *Handler {
+	return &Handler{
+		secret:       secret,
+		maxBodyBytes: DefaultMaxBodyBytes,
+		handlers:     map[Category]HandlerFunc{},
+	}
+}
+
+func (h *Handler) MaxBodyBytes(limit int64) *Handler {
+	h.maxBodyBytes = limit
+	return h
+}
+
+// On registers fn for events of the category, replacing any function registered before.
+func (h *Handler) On(category Category, fn HandlerFunc) *Handler {
+	h.handlers[category] = fn
+	return h
+}
+
+// Default registers fn for events of categories without a function of their own.
+func (h *Handler) Default(fn HandlerFunc) *Handler {
+	h.fallback = fn
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		fail(w, http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			fail(w, http.StatusRequestEntityTooLarge)
+			return
+		}
+		fail(w, http.StatusBadRequest)
+		return
+	}
+
+	headers := map[string]string{}
+	if signature := r.Header.Get("Signature"); signature != "" {
+		headers["Signature"] = signature
+	}
+
+	event, err := (&IncomingWebhook{}).ConstructTypedEvent(string(body), headers, h.secret)
+	if err != nil {
+		var signatureErr *errors.WebhookSignatureException
+		if stderrors.As(err, &signatureErr) {
+			fail(w, http.StatusUnauthorized)
+			return
+		}
+		fail(w, http.StatusBadRequest)
+		return
+	}
+
+	fn, ok := h.handlers[event.Common().Category]
+	if !ok {
+		fn = h.fallback
+	}
+
+	if fn != nil {
+		if err := fn(r.Context(), event); err != nil {
+			fail(w, statusCode(err))
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// fail answers with the bare status text, so the errors of the handler functions,
+// which may name internal systems, never reach the sender.
+func fail(w http.ResponseWriter, code int) {
+	http.Error(w, http.StatusText(code), code)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks a handler error as one retrying will not fix, so Pingen stops delivering the event.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func statusCode(err error) int {
+	var permanent *permanentError
+	if stderrors.As(err, &permanent) {
+		return http.StatusUnprocessableEntity
+	}
+
+	return http.StatusInternalServerError
+}
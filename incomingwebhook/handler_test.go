@@ -0,0 +1,96 @@
+package incomingwebhook_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/incomingwebhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func deliver(handler http.Handler, method, payload, signature string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, "/webhooks/pingen", strings.NewReader(payload))
+	if signature != "" {
+		request.Header.Set("Signature", signature)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestHandler_Routes(t *testing.T) {
+	var issues, fallback []string
+
+	handler := incomingwebhook.NewHandler("secret").
+		On(incomingwebhook.CategoryIssues, func(ctx context.Context, event incomingwebhook.TypedEvent) error {
+			issues = append(issues, event.(*incomingwebhook.IssueEvent).LetterID)
+			return nil
+		}).
+		Default(func(ctx context.Context, event incomingwebhook.TypedEvent) error {
+			fallback = append(fallback, string(event.Common().Category))
+			return nil
+		})
+
+	response := deliver(handler, http.MethodPost, issuePayload, sign(issuePayload, "secret")["Signature"])
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, []string{"4f31cdb2-bc0d-4db5-a13d-3336958dba02"}, issues)
+
+	sent := strings.Replace(issuePayload, "webhook_issues", "webhook_sent", 1)
+	response = deliver(handler, http.MethodPost, sent, sign(sent, "secret")["Signature"])
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, []string{"sent"}, fallback)
+
+	response = deliver(incomingwebhook.NewHandler("secret"), http.MethodPost, sent, sign(sent, "secret")["Signature"])
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func TestHandler_Rejects(t *testing.T) {
+	handler := incomingwebhook.NewHandler("secret").MaxBodyBytes(int64(len(issuePayload)))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, deliver(handler, http.MethodGet, "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, deliver(handler, http.MethodPost, issuePayload, "").Code)
+	assert.Equal(t, http.StatusUnauthorized, deliver(handler, http.MethodPost, issuePayload, sign(issuePayload, "other")["Signature"]).Code)
+	response := deliver(handler, http.MethodPost, "{}", sign("{}", "secret")["Signature"])
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Equal(t, "Bad Request\n", response.Body.String())
+
+	large := issuePayload + " "
+	assert.Equal(t, http.StatusRequestEntityTooLarge, deliver(handler, http.MethodPost, large, sign(large, "secret")["Signature"]).Code)
+}
+
+func TestHandler_ErrorStatus(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{fmt.Errorf("database unavailable"), http.StatusInternalServerError},
+		{incomingwebhook.Permanent(fmt.Errorf("letter unknown")), http.StatusUnprocessableEntity},
+		{errors.NewPingenError("Rate limited", "", http.StatusTooManyRequests, nil), http.StatusInternalServerError},
+		{errors.NewPingenError("Letter not found", "", http.StatusNotFound, nil), http.StatusInternalServerError},
+		{fmt.Errorf("wrapped: %w", errors.NewPingenError("Unavailable", "", http.StatusServiceUnavailable, nil)), http.StatusInternalServerError},
+		{incomingwebhook.Permanent(errors.NewPingenError("Letter not found", "", http.StatusNotFound, nil)), http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			handler := incomingwebhook.NewHandler("secret").
+				On(incomingwebhook.CategoryIssues, func(ctx context.Context, event incomingwebhook.TypedEvent) error {
+					return tt.err
+				})
+
+			response := deliver(handler, http.MethodPost, issuePayload, sign(issuePayload, "secret")["Signature"])
+
+			assert.Equal(t, tt.expected, response.Code)
+			assert.Equal(t, http.StatusText(tt.expected)+"\n", response.Body.String())
+		})
+	}
+
+	assert.Nil(t, incomingwebhook.Permanent(nil))
+}
@@ -32,7 +32,7 @@ func VerifyHeader(payload string, headers map[string]string, secret string) erro
 	h.Write([]byte(payload))
 	expectedSig := hex.EncodeToString(h.Sum(nil))
 
-	if signature != expectedSig {
+	if !hmac.Equal([]byte(signature), []byte(expectedSig)) {
 		return errors.NewWebhookSignatureException("webhook signature matching failed")
 	}
 
@@ -0,0 +1,11 @@
+package letters
+
+import (
+	"github.com/pingencom/pingen2-sdk-go/addresses"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+// Recipient parses the address Pingen recognised on the letter.
+func (r LetterResponse) Recipient() (addresses.Address, *errors.PingenError) {
+	return addresses.Parse(r.Data.Attributes.Address, r.Data.Attributes.Country)
+}
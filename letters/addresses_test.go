@@ -0,0 +1,26 @@
+package letters_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLetterResponseRecipient(t *testing.T) {
+	var letter letters.LetterResponse
+	assert.Nil(t, json.Unmarshal([]byte(mockResponse), &letter))
+
+	recipient, err := letter.Recipient()
+
+	assert.Nil(t, err)
+	assert.Equal(t, letters.RecipientAddress{
+		Name:    "Hans Meier",
+		Street:  "Example street",
+		Number:  "4",
+		Zip:     "8000",
+		City:    "Zürich",
+		Country: "CH",
+	}, recipient)
+}
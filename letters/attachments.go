@@ -0,0 +1,45 @@
+package letters
+
+import (
+	"fmt"
+
+	"github.com/pingencom/pingen2-sdk-go/attachments"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+func (l *Letters) attachments(letterID string) *attachments.Attachments {
+	basePath := fmt.Sprintf("/organisations/%s/letters/%s/attachments", l.organisationID, letterID)
+	return attachments.NewAttachments(basePath, l.apiRequestor)
+}
+
+func (l *Letters) UploadAndAddAttachment(
+	letterID, pathToFile, fileOriginalName string,
+) (attachments.AttachmentResponse, *errors.PingenError) {
+	if err := l.checkAbility(letterID, AbilityAddAttachment); err != nil {
+		return attachments.AttachmentResponse{}, err
+	}
+
+	return l.attachments(letterID).UploadAndCreate(pathToFile, fileOriginalName)
+}
+
+func (l *Letters) AddAttachment(
+	letterID, fileURL, fileSignature, fileOriginalName string,
+) (attachments.AttachmentResponse, *errors.PingenError) {
+	if err := l.checkAbility(letterID, AbilityAddAttachment); err != nil {
+		return attachments.AttachmentResponse{}, err
+	}
+
+	return l.attachments(letterID).Create(fileURL, fileSignature, fileOriginalName)
+}
+
+func (l *Letters) GetAttachments(
+	letterID string,
+	params map[string]string,
+	suppliedHeaders map[string]string,
+) (attachments.AttachmentCollectionResponse, *errors.PingenError) {
+	return l.attachments(letterID).GetCollection(params, suppliedHeaders)
+}
+
+func (l *Letters) DeleteAttachment(letterID, attachmentID string) (interface{}, *errors.PingenError) {
+	return l.attachments(letterID).Delete(attachmentID)
+}
@@ -0,0 +1,78 @@
+package letters_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAttachment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveLetterDetails(w, r) {
+			return
+		}
+
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/attachments", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"file_original_name":"terms.pdf"`)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data": {"id": "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "type": "attachments"}}`))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	resp, err := letterClient.AddAttachment("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "https://s3.example.com/file", "signature", "terms.pdf")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", resp.Data.ID)
+}
+
+func TestUploadAndAddAttachment_AbilityDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	_, err := letterClient.UploadAndAddAttachment("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "testFile.pdf", "terms.pdf")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+}
+
+func TestGetAndDeleteAttachments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/attachments/attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/attachments", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": [{"id": "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "type": "attachments"}], "meta": {"total": 1}}`))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	collection, err := letterClient.GetAttachments("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", nil, nil)
+	assert.Nil(t, err)
+	assert.Len(t, collection.Data, 1)
+
+	resp, err := letterClient.DeleteAttachment("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "attachxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+	assert.Nil(t, err)
+	assert.NotNil(t, resp)
+}
@@ -0,0 +1,59 @@
+package letters
+
+import (
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/deliveryproducts"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+// SetDeliveryCatalog makes Send reject delivery products the catalog does not allow for
+// the letter's country before anything is sent. Nil, the default, leaves validation to the API.
+// Dry-run letters are checked against the recipient country of the meta data they were created with.
+func (l *Letters) SetDeliveryCatalog(catalog *deliveryproducts.Catalog) {
+	l.deliveryCatalog = catalog
+}
+
+// checkSend runs the send transition check and the catalog check on a single lookup of the letter.
+func (l *Letters) checkSend(letterID, deliveryProduct, printMode, printSpectrum string) *errors.PingenError {
+	if l.deliveryCatalog == nil {
+		return l.checkTransition(letterID, ActionSend)
+	}
+
+	product := deliveryproducts.Product(deliveryProduct)
+	mode := deliveryproducts.PrintMode(printMode)
+	spectrum := deliveryproducts.PrintSpectrum(printSpectrum)
+
+	if api.IsDryRunID(letterID) {
+		country, _ := l.dryRunCountries.Load(letterID)
+		countryCode, _ := country.(string)
+		return l.deliveryCatalog.Validate(product, countryCode, mode, spectrum)
+	}
+
+	letter, err := l.GetDetails(letterID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if l.validateTransitions && !letter.CanPerform(ActionSend) {
+		return newInvalidTransitionError(letterID, letter.Status(), string(ActionSend))
+	}
+
+	return l.deliveryCatalog.Validate(product, letter.Data.Attributes.Country, mode, spectrum)
+}
+
+// rememberDryRunCountry keeps the recipient country of a dry-run letter, which the API never
+// reads from the PDF, so Send can still check the delivery product against it.
+func (l *Letters) rememberDryRunCountry(letterID string, metaData map[string]interface{}) {
+	var country string
+
+	switch recipient := metaData["recipient"].(type) {
+	case map[string]string:
+		country = recipient["country"]
+	case map[string]interface{}:
+		country, _ = recipient["country"].(string)
+	}
+
+	if country != "" {
+		l.dryRunCountries.Store(letterID, country)
+	}
+}
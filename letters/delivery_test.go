@@ -0,0 +1,77 @@
+package letters_test
+
+import (
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/pingencom/pingen2-sdk-go/addresses"
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/deliveryproducts"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendLetter_DeliveryCatalog(t *testing.T) {
+	gets, sends := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			gets++
+		} else {
+			sends++
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(grantedLetterResponse))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+	letterClient.SetDeliveryCatalog(deliveryproducts.DefaultCatalog())
+
+	_, err := letterClient.Send("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", string(deliveryproducts.DPAGStandard), "simplex", "color")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, `Delivery product "dpag_standard" is not available for CH`, err.Message)
+	assert.Equal(t, 0, sends)
+
+	_, err = letterClient.Send("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", string(deliveryproducts.PostAGA), "simplex", "color")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, gets)
+	assert.Equal(t, 1, sends)
+}
+
+func TestSendLetter_DeliveryCatalogDryRun(t *testing.T) {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL("http://invalid-url")
+	config.SetDryRun(true)
+	config.SetDryRunLogger(log.New(io.Discard, "", 0))
+	letterClient := letters.NewLetters("testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", api.NewAPIRequestor("dummyToken", config))
+	letterClient.SetDeliveryCatalog(deliveryproducts.DefaultCatalog())
+
+	recipient := addresses.Address{Name: "Hans Meier", Street: "Example street", Number: "4", Zip: "9490", City: "Vaduz", Country: "LI"}
+	letter, err := letterClient.Create("https://upload.example.com/file", "signature", "invoice.pdf", "left", false, "", "", "", "", addresses.MetaData(recipient, recipient), nil)
+	assert.Nil(t, err)
+
+	_, err = letterClient.Send(letter.Data.ID, string(deliveryproducts.IndpostMail), "simplex", "color")
+	assert.NotNil(t, err)
+	assert.Equal(t, `Delivery product "indpost_mail" is not available for LI`, err.Message)
+
+	_, err = letterClient.Send(letter.Data.ID, string(deliveryproducts.PostAGA), "simplex", "color")
+	assert.Nil(t, err)
+
+	unknown, err := letterClient.Create("https://upload.example.com/file", "signature", "invoice.pdf", "left", false, "", "", "", "", nil, nil)
+	assert.Nil(t, err)
+
+	_, err = letterClient.Send(unknown.Data.ID, string(deliveryproducts.PostAGA), "simplex", "color")
+	assert.NotNil(t, err)
+	assert.Equal(t, `Delivery product "postag_a" cannot be checked without a country`, err.Message)
+
+	_, err = letterClient.Send(unknown.Data.ID, string(deliveryproducts.Fast), "simplex", "color")
+	assert.Nil(t, err)
+}
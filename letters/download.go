@@ -0,0 +1,64 @@
+package letters
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+type FileVariant string
+
+const (
+	FileVariantProcessed  FileVariant = "processed"
+	FileVariantRaw        FileVariant = "raw"
+	FileVariantValidation FileVariant = "validation"
+)
+
+const pdfContentType = "application/pdf"
+
+func (l *Letters) Download(letterID string, variant FileVariant, w io.Writer) (api.DownloadResult, *errors.PingenError) {
+	url, err := l.fileURL(letterID, variant)
+	if err != nil {
+		return api.DownloadResult{}, err
+	}
+
+	return l.apiRequestor.PerformDownloadRequest(url, w, pdfContentType)
+}
+
+func (l *Letters) DownloadToFile(letterID string, variant FileVariant, path string) (api.DownloadResult, *errors.PingenError) {
+	url, err := l.fileURL(letterID, variant)
+	if err != nil {
+		return api.DownloadResult{}, err
+	}
+
+	return l.apiRequestor.PerformDownloadToFile(url, path, pdfContentType)
+}
+
+func (l *Letters) fileURL(letterID string, variant FileVariant) (string, *errors.PingenError) {
+	base := fmt.Sprintf("/organisations/%s/letters/%s/file", l.organisationID, letterID)
+
+	switch variant {
+	case FileVariantProcessed, "":
+		return base, nil
+	case FileVariantRaw:
+		if err := l.checkAbility(letterID, AbilityGetPdfRaw); err != nil {
+			return "", err
+		}
+		return base + "/raw", nil
+	case FileVariantValidation:
+		if err := l.checkAbility(letterID, AbilityGetPdfValidation); err != nil {
+			return "", err
+		}
+		return base + "/validation", nil
+	default:
+		return "", errors.NewPingenError(
+			fmt.Sprintf("Unknown file variant %q", variant),
+			"",
+			http.StatusBadRequest,
+			nil,
+		)
+	}
+}
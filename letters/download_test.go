@@ -0,0 +1,121 @@
+package letters_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownload(t *testing.T) {
+	testCases := []struct {
+		variant      letters.FileVariant
+		expectedPath string
+	}{
+		{letters.FileVariantProcessed, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/file"},
+		{letters.FileVariantRaw, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/file/raw"},
+		{letters.FileVariantValidation, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/file/validation"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.variant), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1" {
+					serveLetterDetails(w, r)
+					return
+				}
+
+				assert.Equal(t, tc.expectedPath, r.URL.Path)
+				assert.Equal(t, http.MethodGet, r.Method)
+
+				w.Header().Set("Content-Type", "application/pdf")
+				w.WriteHeader(http.StatusOK)
+				_, _ = io.WriteString(w, "mock file content")
+			}))
+			defer server.Close()
+
+			letterClient := setupLetter(server.URL)
+
+			var buf bytes.Buffer
+			result, err := letterClient.Download("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", tc.variant, &buf)
+
+			assert.Nil(t, err)
+			assert.Equal(t, "mock file content", buf.String())
+			assert.Equal(t, int64(17), result.Size)
+			assert.Len(t, result.SHA256, 64)
+		})
+	}
+}
+
+func TestDownload_UnknownVariant(t *testing.T) {
+	letterClient := setupLetter("http://invalid-url")
+
+	var buf bytes.Buffer
+	_, err := letterClient.Download("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", letters.FileVariant("thumbnail"), &buf)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.StatusCode)
+}
+
+func TestDownload_AbilityDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(strings.Replace(mockResponse, `"get-pdf-raw": "ok"`, `"get-pdf-raw": "state"`, 1)))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	var buf bytes.Buffer
+	_, err := letterClient.Download("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", letters.FileVariantRaw, &buf)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+}
+
+func TestDownloadToFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/file", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, "mock file content")
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+	path := filepath.Join(t.TempDir(), "letter.pdf")
+
+	result, err := letterClient.DownloadToFile("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", letters.FileVariantProcessed, path)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "application/pdf", result.ContentType)
+
+	content, _ := os.ReadFile(path)
+	assert.Equal(t, "mock file content", string(content))
+}
+
+func TestDownloadToFile_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+	dir := t.TempDir()
+
+	_, err := letterClient.DownloadToFile("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", letters.FileVariantProcessed, filepath.Join(dir, "letter.pdf"))
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnauthorized, err.StatusCode)
+
+	entries, _ := os.ReadDir(dir)
+	assert.Len(t, entries, 0)
+}
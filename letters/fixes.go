@@ -0,0 +1,125 @@
+package letters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingencom/pingen2-sdk-go/addresses"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+type AddressPosition string
+
+const (
+	AddressPositionLeft  AddressPosition = "left"
+	AddressPositionRight AddressPosition = "right"
+)
+
+type RecipientAddress = addresses.Address
+
+type FixAddressInput struct {
+	Address RecipientAddress
+}
+
+type FixCountryInput struct {
+	Country string
+}
+
+type ChangeWindowPositionInput struct {
+	AddressPosition AddressPosition
+}
+
+type CreateCoverpageInput struct {
+	Address         RecipientAddress
+	AddressPosition AddressPosition
+}
+
+func (l *Letters) FixAddress(letterID string, input FixAddressInput) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityFixAddress, map[string]interface{}{
+		"address": input.Address,
+	}, nil)
+}
+
+func (l *Letters) FixCountry(letterID string, input FixCountryInput) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityFixCountry, map[string]interface{}{
+		"country": input.Country,
+	}, nil)
+}
+
+func (l *Letters) FixCoverpage(letterID string) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityFixCoverPage, nil, nil)
+}
+
+func (l *Letters) FixRegularPaper(letterID string) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityFixRegularPaper, nil, nil)
+}
+
+func (l *Letters) FixOverwriteRestrictedAreas(letterID string) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityFixOverwriteRestrictedAreas, nil, nil)
+}
+
+func (l *Letters) FixInteractiveContent(letterID string) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityFixInteractiveContent, nil, nil)
+}
+
+func (l *Letters) FixFormat(letterID string) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityFixFormat, nil, nil)
+}
+
+func (l *Letters) RestoreOriginal(letterID string) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityRestoreOriginal, nil, nil)
+}
+
+func (l *Letters) ChangeWindowPosition(letterID string, input ChangeWindowPositionInput) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityChangeWindowPosition, map[string]interface{}{
+		"address_position": string(input.AddressPosition),
+	}, nil)
+}
+
+func (l *Letters) CreateCoverpage(letterID string, input CreateCoverpageInput) (LetterResponse, *errors.PingenError) {
+	attributes := map[string]interface{}{
+		"address": input.Address,
+	}
+
+	if input.AddressPosition != "" {
+		attributes["address_position"] = string(input.AddressPosition)
+	}
+
+	return l.performAction(letterID, AbilityCreateCoverpage, attributes, nil)
+}
+
+// Letter actions share one shape: PATCH /letters/{id}/{ability} with optional attributes and relationships.
+func (l *Letters) performAction(
+	letterID string,
+	ability Ability,
+	attributes, relationships map[string]interface{},
+) (LetterResponse, *errors.PingenError) {
+	if err := l.checkAbility(letterID, ability); err != nil {
+		return LetterResponse{}, err
+	}
+
+	data := map[string]interface{}{
+		"id":   letterID,
+		"type": "letters",
+	}
+
+	if attributes != nil {
+		data["attributes"] = attributes
+	}
+
+	if relationships != nil {
+		data["relationships"] = relationships
+	}
+
+	payload, _ := json.Marshal(map[string]interface{}{"data": data})
+	url := fmt.Sprintf("/organisations/%s/letters/%s/%s", l.organisationID, letterID, ability)
+
+	var response LetterResponse
+
+	_, err := l.apiRequestor.PerformPatchRequest(url, &response, payload, nil)
+	if err != nil {
+		return LetterResponse{}, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,164 @@
+package letters_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixActions(t *testing.T) {
+	address := letters.RecipientAddress{
+		Name:    "Hans Meier",
+		Street:  "Example street",
+		Number:  "4",
+		Zip:     "8000",
+		City:    "Zürich",
+		Country: "CH",
+	}
+	addressJSON := `{"name":"Hans Meier","street":"Example street","number":"4","zip":"8000","city":"Zürich","country":"CH"}`
+
+	testCases := []struct {
+		name         string
+		action       string
+		expectedBody string
+		call         func(client *letters.Letters, letterID string) (letters.LetterResponse, *errors.PingenError)
+	}{
+		{
+			name:         "FixAddress",
+			action:       "fix-address",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters","attributes":{"address":` + addressJSON + `}}}`,
+			call: func(client *letters.Letters, letterID string) (letters.LetterResponse, *errors.PingenError) {
+				return client.FixAddress(letterID, letters.FixAddressInput{Address: address})
+			},
+		},
+		{
+			name:         "FixCountry",
+			action:       "fix-country",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters","attributes":{"country":"DE"}}}`,
+			call: func(client *letters.Letters, letterID string) (letters.LetterResponse, *errors.PingenError) {
+				return client.FixCountry(letterID, letters.FixCountryInput{Country: "DE"})
+			},
+		},
+		{
+			name:         "FixCoverpage",
+			action:       "fix-coverpage",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters"}}`,
+			call:         (*letters.Letters).FixCoverpage,
+		},
+		{
+			name:         "FixRegularPaper",
+			action:       "fix-regular-paper",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters"}}`,
+			call:         (*letters.Letters).FixRegularPaper,
+		},
+		{
+			name:         "FixOverwriteRestrictedAreas",
+			action:       "fix-overwrite-restricted-areas",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters"}}`,
+			call:         (*letters.Letters).FixOverwriteRestrictedAreas,
+		},
+		{
+			name:         "FixInteractiveContent",
+			action:       "fix-interactive-content",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters"}}`,
+			call:         (*letters.Letters).FixInteractiveContent,
+		},
+		{
+			name:         "FixFormat",
+			action:       "fix-format",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters"}}`,
+			call:         (*letters.Letters).FixFormat,
+		},
+		{
+			name:         "RestoreOriginal",
+			action:       "restore-original",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters"}}`,
+			call:         (*letters.Letters).RestoreOriginal,
+		},
+		{
+			name:         "ChangeWindowPosition",
+			action:       "change-window-position",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters","attributes":{"address_position":"right"}}}`,
+			call: func(client *letters.Letters, letterID string) (letters.LetterResponse, *errors.PingenError) {
+				return client.ChangeWindowPosition(letterID, letters.ChangeWindowPositionInput{
+					AddressPosition: letters.AddressPositionRight,
+				})
+			},
+		},
+		{
+			name:         "CreateCoverpage",
+			action:       "create-coverpage",
+			expectedBody: `{"data":{"id":"xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1","type":"letters","attributes":{"address":` + addressJSON + `,"address_position":"left"}}}`,
+			call: func(client *letters.Letters, letterID string) (letters.LetterResponse, *errors.PingenError) {
+				return client.CreateCoverpage(letterID, letters.CreateCoverpageInput{
+					Address:         address,
+					AddressPosition: letters.AddressPositionLeft,
+				})
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if serveLetterDetails(w, r) {
+					return
+				}
+
+				assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/"+tc.action, r.URL.Path)
+				assert.Equal(t, http.MethodPatch, r.Method)
+
+				body, _ := io.ReadAll(r.Body)
+				assert.JSONEq(t, tc.expectedBody, string(body))
+
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(mockResponse))
+			}))
+			defer server.Close()
+
+			letterClient := setupLetter(server.URL)
+
+			resp, err := tc.call(letterClient, "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+
+			assert.Nil(t, err)
+			assert.Equal(t, "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", resp.Data.ID)
+		})
+	}
+}
+
+func TestFixAction_AbilityDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	_, err := letterClient.FixFormat("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, "PingenError: Letter cannot fix-format in status \"send\" (Status Code: 409, Request ID: )", err.Error())
+}
+
+func TestFixAction_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+	letterClient.SetTransitionValidation(false)
+
+	_, err := letterClient.FixCountry("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", letters.FixCountryInput{Country: "CH"})
+
+	assert.NotNil(t, err)
+	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
+	assert.Equal(t, expectedMessage, err.Error())
+}
@@ -4,15 +4,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 
 	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/deliveryproducts"
 	"github.com/pingencom/pingen2-sdk-go/errors"
 	"github.com/pingencom/pingen2-sdk-go/fileupload"
 )
 
 type Letters struct {
-	organisationID string
-	apiRequestor   *api.APIRequestor
+	organisationID      string
+	apiRequestor        *api.APIRequestor
+	validateTransitions bool
+	deliveryCatalog     *deliveryproducts.Catalog
+	dryRunCountries     sync.Map
 }
 
 type LetterResponse struct {
@@ -106,68 +111,70 @@ type LetterResponse struct {
 	Included []struct{} `json:"included"`
 }
 
+type LetterCollectionItem struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Attributes struct {
+		Status           string   `json:"status"`
+		FileOriginalName string   `json:"file_original_name"`
+		FilePages        int      `json:"file_pages"`
+		Address          string   `json:"address"`
+		AddressPosition  string   `json:"address_position"`
+		Country          string   `json:"country"`
+		DeliveryProduct  string   `json:"delivery_product"`
+		PrintMode        string   `json:"print_mode"`
+		PrintSpectrum    string   `json:"print_spectrum"`
+		PriceCurrency    string   `json:"price_currency"`
+		PriceValue       float64  `json:"price_value"`
+		PaperTypes       []string `json:"paper_types"`
+		Fonts            []struct {
+			Name       string `json:"name"`
+			IsEmbedded bool   `json:"is_embedded"`
+		} `json:"fonts"`
+		Source         string `json:"source"`
+		TrackingNumber string `json:"tracking_number"`
+		SubmittedAt    string `json:"submitted_at"`
+		CreatedAt      string `json:"created_at"`
+		UpdatedAt      string `json:"updated_at"`
+	} `json:"attributes"`
+	Relationships struct {
+		Organisation struct {
+			Links struct {
+				Related string `json:"related"`
+			} `json:"links"`
+			Data struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			} `json:"data"`
+		} `json:"organisation"`
+		Events struct {
+			Links struct {
+				Related struct {
+					Href string `json:"href"`
+					Meta struct {
+						Count int `json:"count"`
+					} `json:"meta"`
+				} `json:"related"`
+			} `json:"links"`
+		} `json:"events"`
+		Batch struct {
+			Links struct {
+				Related string `json:"related"`
+			} `json:"links"`
+			Data struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			} `json:"data"`
+		} `json:"batch"`
+	} `json:"relationships"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"links"`
+}
+
 type LetterCollectionResponse struct {
-	Data []struct {
-		ID         string `json:"id"`
-		Type       string `json:"type"`
-		Attributes struct {
-			Status           string   `json:"status"`
-			FileOriginalName string   `json:"file_original_name"`
-			FilePages        int      `json:"file_pages"`
-			Address          string   `json:"address"`
-			AddressPosition  string   `json:"address_position"`
-			Country          string   `json:"country"`
-			DeliveryProduct  string   `json:"delivery_product"`
-			PrintMode        string   `json:"print_mode"`
-			PrintSpectrum    string   `json:"print_spectrum"`
-			PriceCurrency    string   `json:"price_currency"`
-			PriceValue       float64  `json:"price_value"`
-			PaperTypes       []string `json:"paper_types"`
-			Fonts            []struct {
-				Name       string `json:"name"`
-				IsEmbedded bool   `json:"is_embedded"`
-			} `json:"fonts"`
-			Source         string `json:"source"`
-			TrackingNumber string `json:"tracking_number"`
-			SubmittedAt    string `json:"submitted_at"`
-			CreatedAt      string `json:"created_at"`
-			UpdatedAt      string `json:"updated_at"`
-		} `json:"attributes"`
-		Relationships struct {
-			Organisation struct {
-				Links struct {
-					Related string `json:"related"`
-				} `json:"links"`
-				Data struct {
-					ID   string `json:"id"`
-					Type string `json:"type"`
-				} `json:"data"`
-			} `json:"organisation"`
-			Events struct {
-				Links struct {
-					Related struct {
-						Href string `json:"href"`
-						Meta struct {
-							Count int `json:"count"`
-						} `json:"meta"`
-					} `json:"related"`
-				} `json:"links"`
-			} `json:"events"`
-			Batch struct {
-				Links struct {
-					Related string `json:"related"`
-				} `json:"links"`
-				Data struct {
-					ID   string `json:"id"`
-					Type string `json:"type"`
-				} `json:"data"`
-			} `json:"batch"`
-		} `json:"relationships"`
-		Links struct {
-			Self string `json:"self"`
-		} `json:"links"`
-	} `json:"data"`
-	Included []struct{} `json:"included"`
+	Data     []LetterCollectionItem `json:"data"`
+	Included []struct{}             `json:"included"`
 	Links    struct {
 		First string `json:"first"`
 		Last  string `json:"last"`
@@ -198,8 +205,9 @@ type PriceCalculationResponse struct {
 
 func NewLetters(organisationID string, apiRequestor *api.APIRequestor) *Letters {
 	return &Letters{
-		organisationID: organisationID,
-		apiRequestor:   apiRequestor,
+		organisationID:      organisationID,
+		apiRequestor:        apiRequestor,
+		validateTransitions: true,
 	}
 }
 
@@ -315,10 +323,18 @@ func (l *Letters) Create(
 		return LetterResponse{}, err
 	}
 
+	if api.IsDryRunID(response.Data.ID) {
+		l.rememberDryRunCountry(response.Data.ID, metaData)
+	}
+
 	return response, nil
 }
 
 func (l *Letters) Send(letterID, deliveryProduct, printMode, printSpectrum string) (LetterResponse, *errors.PingenError) {
+	if err := l.checkSend(letterID, deliveryProduct, printMode, printSpectrum); err != nil {
+		return LetterResponse{}, err
+	}
+
 	payload := map[string]interface{}{
 		"data": map[string]interface{}{
 			"id":   letterID,
@@ -342,20 +358,31 @@ func (l *Letters) Send(letterID, deliveryProduct, printMode, printSpectrum strin
 	}
 
 	return response, nil
-
 }
 
 func (l *Letters) Cancel(letterID string) (interface{}, *errors.PingenError) {
+	if err := l.checkTransition(letterID, ActionCancel); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("/organisations/%s/letters/%s/cancel", l.organisationID, letterID)
 	return l.apiRequestor.PerformCancelRequest(url)
 }
 
 func (l *Letters) Delete(letterID string) (interface{}, *errors.PingenError) {
+	if err := l.checkTransition(letterID, ActionDelete); err != nil {
+		return nil, err
+	}
+
 	url := fmt.Sprintf("/organisations/%s/letters/%s", l.organisationID, letterID)
 	return l.apiRequestor.PerformDeleteRequest(url)
 }
 
 func (l *Letters) Edit(letterID string, paperTypes []string) (LetterResponse, *errors.PingenError) {
+	if err := l.checkTransition(letterID, ActionEdit); err != nil {
+		return LetterResponse{}, err
+	}
+
 	payload := map[string]interface{}{
 		"data": map[string]interface{}{
 			"id":   letterID,
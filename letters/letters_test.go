@@ -1,10 +1,13 @@
 package letters_test
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/pingencom/pingen2-sdk-go"
@@ -111,6 +114,21 @@ const mockResponse = `{
 	"included": [{}]
 }`
 
+var grantedLetterResponse = strings.NewReplacer(
+	`"status": "send"`, `"status": "valid"`,
+	`"state"`, `"ok"`,
+).Replace(mockResponse)
+
+func serveLetterDetails(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet {
+		return false
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(grantedLetterResponse))
+	return true
+}
+
 func setupUnauthorizedServer() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/vnd.api+json")
@@ -508,6 +526,10 @@ func TestCreate_Error(t *testing.T) {
 
 func TestSendLetter(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveLetterDetails(w, r) {
+			return
+		}
+
 		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/send", r.URL.Path)
 		assert.Equal(t, http.MethodPatch, r.Method)
 
@@ -543,6 +565,10 @@ func TestSendLetter_Error(t *testing.T) {
 
 func TestCancelLetter(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveLetterDetails(w, r) {
+			return
+		}
+
 		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/test-letter-id/cancel", r.URL.Path)
 		assert.Equal(t, http.MethodPatch, r.Method)
 
@@ -560,6 +586,10 @@ func TestCancelLetter(t *testing.T) {
 
 func TestDeleteLetter(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveLetterDetails(w, r) {
+			return
+		}
+
 		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/test-letter-id", r.URL.Path)
 		assert.Equal(t, http.MethodDelete, r.Method)
 
@@ -577,6 +607,10 @@ func TestDeleteLetter(t *testing.T) {
 
 func TestEdit(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveLetterDetails(w, r) {
+			return
+		}
+
 		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
 		assert.Equal(t, http.MethodPatch, r.Method)
 
@@ -691,3 +725,58 @@ func TestCalculatePrice_Error(t *testing.T) {
 	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
 	assert.Equal(t, expectedMessage, err.Error())
 }
+
+func TestDryRun_CreateAndSend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/file-upload", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"attributes": {"url": "https://upload.example.com/file", "url_signature": "signature"}}}`))
+	}))
+	defer server.Close()
+
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(server.URL)
+	config.SetDryRun(true)
+	config.SetDryRunLogger(log.New(io.Discard, "", 0))
+	letterClient := letters.NewLetters("testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", api.NewAPIRequestor("dummyToken", config))
+
+	letter, err := letterClient.UploadAndCreate("testFile.pdf", "invoice.pdf", "left", false, "fast", "simplex", "color", "", nil, nil)
+	assert.Nil(t, err)
+	assert.True(t, api.IsDryRunID(letter.Data.ID))
+	assert.Equal(t, letters.StatusValidating, letter.Status())
+
+	sent, err := letterClient.Send(letter.Data.ID, "fast", "simplex", "color")
+	assert.Nil(t, err)
+	assert.Equal(t, letter.Data.ID, sent.Data.ID)
+	assert.Equal(t, letters.StatusSubmitted, sent.Status())
+}
+
+func TestDryRun_CalculatePriceIsSent(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/price-calculator", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data": {"type": "letter_price_calculator", "attributes": {"currency": "CHF", "price": 1.25}}}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(server.URL)
+	config.SetDryRun(true)
+	config.SetDryRunLogger(log.New(&logs, "", 0))
+	letterClient := letters.NewLetters("testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", api.NewAPIRequestor("dummyToken", config))
+
+	price, err := letterClient.CalculatePrice("CH", []string{"normal"}, "simplex", "color", "cheap")
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, "CHF", price.Data.Attributes.Currency)
+	assert.Equal(t, 1.25, price.Data.Attributes.Price)
+	assert.Empty(t, logs.String())
+}
@@ -0,0 +1,272 @@
+package letters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/response"
+)
+
+type Status string
+
+const (
+	StatusValidating     Status = "validating"
+	StatusValid          Status = "valid"
+	StatusInvalid        Status = "invalid"
+	StatusActionRequired Status = "action_required"
+	StatusSubmitted      Status = "submitted"
+	StatusAccepted       Status = "accepted"
+	StatusPrinting       Status = "printing"
+	StatusSent           Status = "sent"
+	StatusUndeliverable  Status = "undeliverable"
+	StatusCancelling     Status = "cancelling"
+	StatusCancelled      Status = "cancelled"
+	StatusExpired        Status = "expired"
+)
+
+type Action string
+
+const (
+	ActionSend   Action = "send"
+	ActionCancel Action = "cancel"
+	ActionEdit   Action = "edit"
+	ActionDelete Action = "delete"
+)
+
+type Ability string
+
+const (
+	AbilityCancel                      Ability = "cancel"
+	AbilityDelete                      Ability = "delete"
+	AbilitySubmit                      Ability = "submit"
+	AbilitySendSimplex                 Ability = "send-simplex"
+	AbilityEdit                        Ability = "edit"
+	AbilityGetPdfRaw                   Ability = "get-pdf-raw"
+	AbilityGetPdfValidation            Ability = "get-pdf-validation"
+	AbilityRestoreOriginal             Ability = "restore-original"
+	AbilityChangePaperType             Ability = "change-paper-type"
+	AbilityChangeWindowPosition        Ability = "change-window-position"
+	AbilityCreateCoverpage             Ability = "create-coverpage"
+	AbilityAddAttachment               Ability = "add-attachment"
+	AbilityFixOverwriteRestrictedAreas Ability = "fix-overwrite-restricted-areas"
+	AbilityFixCoverPage                Ability = "fix-coverpage"
+	AbilityFixCountry                  Ability = "fix-country"
+	AbilityFixRegularPaper             Ability = "fix-regular-paper"
+	AbilityFixAddress                  Ability = "fix-address"
+	AbilityFixInteractiveContent       Ability = "fix-interactive-content"
+	AbilityFixFormat                   Ability = "fix-format"
+	AbilityApplyPreset                 Ability = "apply-preset"
+	AbilityCreatePreset                Ability = "create-preset"
+)
+
+type transition struct {
+	from    []Status
+	to      Status
+	ability Ability
+}
+
+// Statuses a letter has to be in for an action to be accepted, and the status it moves to.
+// Deleting removes the letter, so it has no target status.
+var transitions = map[Action]transition{
+	ActionSend: {
+		from:    []Status{StatusValid},
+		to:      StatusSubmitted,
+		ability: AbilitySubmit,
+	},
+	ActionCancel: {
+		from:    []Status{StatusSubmitted, StatusAccepted},
+		to:      StatusCancelling,
+		ability: AbilityCancel,
+	},
+	ActionEdit: {
+		from:    []Status{StatusValid, StatusActionRequired},
+		to:      StatusValidating,
+		ability: AbilityEdit,
+	},
+	ActionDelete: {
+		from:    []Status{StatusValid, StatusInvalid, StatusActionRequired, StatusCancelled, StatusExpired},
+		ability: AbilityDelete,
+	},
+}
+
+func (s Status) CanTransition(action Action) bool {
+	t, ok := transitions[action]
+	if !ok {
+		return false
+	}
+
+	for _, from := range t.from {
+		if s == from {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s Status) Next(action Action) (Status, bool) {
+	if !s.CanTransition(action) {
+		return "", false
+	}
+
+	return transitions[action].to, true
+}
+
+func (s Status) IsTerminal() bool {
+	switch s {
+	case StatusInvalid, StatusSent, StatusUndeliverable, StatusCancelled, StatusExpired:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r LetterResponse) Status() Status {
+	return Status(r.Data.Attributes.Status)
+}
+
+func (r LetterResponse) AbilityValue(ability Ability) string {
+	self := r.Data.Meta.Abilities.Self
+
+	switch ability {
+	case AbilityCancel:
+		return self.Cancel
+	case AbilityDelete:
+		return self.Delete
+	case AbilitySubmit:
+		return self.Submit
+	case AbilitySendSimplex:
+		return self.SendSimplex
+	case AbilityEdit:
+		return self.Edit
+	case AbilityGetPdfRaw:
+		return self.GetPdfRaw
+	case AbilityGetPdfValidation:
+		return self.GetPdfValidation
+	case AbilityRestoreOriginal:
+		return self.RestoreOriginal
+	case AbilityChangePaperType:
+		return self.ChangePaperType
+	case AbilityChangeWindowPosition:
+		return self.ChangeWindowPosition
+	case AbilityCreateCoverpage:
+		return self.CreateCoverpage
+	case AbilityAddAttachment:
+		return self.AddAttachment
+	case AbilityFixOverwriteRestrictedAreas:
+		return self.FixOverwriteRestrictedAreas
+	case AbilityFixCoverPage:
+		return self.FixCoverPage
+	case AbilityFixCountry:
+		return self.FixCountry
+	case AbilityFixRegularPaper:
+		return self.FixRegularPaper
+	case AbilityFixAddress:
+		return self.FixAddress
+	case AbilityFixInteractiveContent:
+		return self.FixInteractiveContent
+	case AbilityFixFormat:
+		return self.FixFormat
+	case AbilityApplyPreset:
+		return self.ApplyPreset
+	case AbilityCreatePreset:
+		return self.CreatePreset
+	default:
+		return ""
+	}
+}
+
+func (r LetterResponse) Can(ability Ability) bool {
+	return r.AbilityValue(ability) == response.AbilityOK
+}
+
+// CanPerform prefers the abilities reported by the API and falls back to the
+// local state machine when the response carries none.
+func (r LetterResponse) CanPerform(action Action) bool {
+	t, ok := transitions[action]
+	if !ok {
+		return false
+	}
+
+	if value := r.AbilityValue(t.ability); value != "" {
+		return value == response.AbilityOK
+	}
+
+	return r.Status().CanTransition(action)
+}
+
+func (r LetterResponse) CanSend() bool {
+	return r.CanPerform(ActionSend)
+}
+
+func (r LetterResponse) CanSendSimplex() bool {
+	return r.Can(AbilitySendSimplex)
+}
+
+func (r LetterResponse) CanCancel() bool {
+	return r.CanPerform(ActionCancel)
+}
+
+func (r LetterResponse) CanEdit() bool {
+	return r.CanPerform(ActionEdit)
+}
+
+func (r LetterResponse) CanDelete() bool {
+	return r.CanPerform(ActionDelete)
+}
+
+func (l *Letters) SetTransitionValidation(enabled bool) {
+	l.validateTransitions = enabled
+}
+
+func (l *Letters) checkTransition(letterID string, action Action) *errors.PingenError {
+	if !l.validateTransitions || api.IsDryRunID(letterID) {
+		return nil
+	}
+
+	letter, err := l.GetDetails(letterID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if letter.CanPerform(action) {
+		return nil
+	}
+
+	return newInvalidTransitionError(letterID, letter.Status(), string(action))
+}
+
+func (l *Letters) checkAbility(letterID string, ability Ability) *errors.PingenError {
+	if !l.validateTransitions || api.IsDryRunID(letterID) {
+		return nil
+	}
+
+	letter, err := l.GetDetails(letterID, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if value := letter.AbilityValue(ability); value == "" || value == response.AbilityOK {
+		return nil
+	}
+
+	return newInvalidTransitionError(letterID, letter.Status(), string(ability))
+}
+
+func newInvalidTransitionError(letterID string, status Status, action string) *errors.PingenError {
+	body, _ := json.Marshal(map[string]string{
+		"letter_id": letterID,
+		"status":    string(status),
+		"action":    action,
+	})
+
+	return errors.NewPingenError(
+		fmt.Sprintf("Letter cannot %s in status %q", action, status),
+		string(body),
+		http.StatusConflict,
+		nil,
+	)
+}
@@ -0,0 +1,149 @@
+package letters_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusCanTransition(t *testing.T) {
+	assert.True(t, letters.StatusValid.CanTransition(letters.ActionSend))
+	assert.False(t, letters.StatusValidating.CanTransition(letters.ActionSend))
+	assert.False(t, letters.StatusActionRequired.CanTransition(letters.ActionSend))
+
+	assert.True(t, letters.StatusSubmitted.CanTransition(letters.ActionCancel))
+	assert.False(t, letters.StatusSent.CanTransition(letters.ActionCancel))
+
+	assert.True(t, letters.StatusActionRequired.CanTransition(letters.ActionEdit))
+	assert.True(t, letters.StatusCancelled.CanTransition(letters.ActionDelete))
+	assert.False(t, letters.StatusPrinting.CanTransition(letters.ActionDelete))
+
+	assert.False(t, letters.StatusValid.CanTransition(letters.Action("unknown")))
+}
+
+func TestStatusNext(t *testing.T) {
+	next, ok := letters.StatusValid.Next(letters.ActionSend)
+	assert.True(t, ok)
+	assert.Equal(t, letters.StatusSubmitted, next)
+
+	next, ok = letters.StatusAccepted.Next(letters.ActionCancel)
+	assert.True(t, ok)
+	assert.Equal(t, letters.StatusCancelling, next)
+
+	_, ok = letters.StatusSent.Next(letters.ActionSend)
+	assert.False(t, ok)
+}
+
+func TestStatusIsTerminal(t *testing.T) {
+	assert.True(t, letters.StatusSent.IsTerminal())
+	assert.True(t, letters.StatusCancelled.IsTerminal())
+	assert.False(t, letters.StatusValidating.IsTerminal())
+	assert.False(t, letters.StatusSubmitted.IsTerminal())
+}
+
+func TestLetterResponseAbilities(t *testing.T) {
+	var letter letters.LetterResponse
+	assert.Nil(t, json.Unmarshal([]byte(mockResponse), &letter))
+
+	assert.Equal(t, letters.Status("send"), letter.Status())
+	assert.Equal(t, "ok", letter.AbilityValue(letters.AbilityGetPdfRaw))
+	assert.Equal(t, "state", letter.AbilityValue(letters.AbilityFixAddress))
+	assert.True(t, letter.Can(letters.AbilityGetPdfValidation))
+	assert.False(t, letter.CanSend())
+	assert.False(t, letter.CanSendSimplex())
+	assert.False(t, letter.CanCancel())
+	assert.False(t, letter.CanEdit())
+	assert.False(t, letter.CanDelete())
+
+	assert.Nil(t, json.Unmarshal([]byte(grantedLetterResponse), &letter))
+
+	assert.True(t, letter.CanSend())
+	assert.True(t, letter.CanSendSimplex())
+	assert.True(t, letter.CanCancel())
+	assert.True(t, letter.CanEdit())
+	assert.True(t, letter.CanDelete())
+}
+
+func TestLetterResponseAbilities_FallbackToStatus(t *testing.T) {
+	var letter letters.LetterResponse
+	letter.Data.Attributes.Status = string(letters.StatusValid)
+
+	assert.True(t, letter.CanSend())
+	assert.True(t, letter.CanEdit())
+	assert.False(t, letter.CanCancel())
+	assert.False(t, letter.Can(letters.AbilitySendSimplex))
+}
+
+func TestSendLetter_InvalidTransition(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	_, err := letterClient.Send("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "fast", "simplex", "color")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+	assert.Equal(t, "PingenError: Letter cannot send in status \"send\" (Status Code: 409, Request ID: )", err.Error())
+	assert.Equal(t, map[string]interface{}{
+		"letter_id": "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1",
+		"status":    "send",
+		"action":    "send",
+	}, err.JSONBody)
+}
+
+func TestCancelDeleteEdit_InvalidTransition(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	_, err := letterClient.Cancel("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+
+	_, err = letterClient.Delete("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+
+	_, err = letterClient.Edit("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", []string{"normal"})
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+}
+
+func TestSendLetter_TransitionValidationDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/send", r.URL.Path)
+		assert.Equal(t, http.MethodPatch, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+	letterClient.SetTransitionValidation(false)
+
+	resp, err := letterClient.Send("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "fast", "simplex", "color")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", resp.Data.ID)
+}
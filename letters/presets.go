@@ -0,0 +1,40 @@
+package letters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/presets"
+)
+
+func (l *Letters) ApplyPreset(letterID, presetID string) (LetterResponse, *errors.PingenError) {
+	return l.performAction(letterID, AbilityApplyPreset, nil, presets.Relationship(presetID))
+}
+
+func (l *Letters) CreatePresetFrom(letterID, name string) (presets.PresetResponse, *errors.PingenError) {
+	if err := l.checkAbility(letterID, AbilityCreatePreset); err != nil {
+		return presets.PresetResponse{}, err
+	}
+
+	payload := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type": "presets",
+			"attributes": map[string]string{
+				"name": name,
+			},
+		},
+	}
+
+	data, _ := json.Marshal(payload)
+	url := fmt.Sprintf("/organisations/%s/letters/%s/%s", l.organisationID, letterID, AbilityCreatePreset)
+
+	var response presets.PresetResponse
+
+	_, err := l.apiRequestor.PerformPostRequest(url, &response, data, nil)
+	if err != nil {
+		return presets.PresetResponse{}, err
+	}
+
+	return response, nil
+}
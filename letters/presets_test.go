@@ -0,0 +1,85 @@
+package letters_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPreset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveLetterDetails(w, r) {
+			return
+		}
+
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/apply-preset", r.URL.Path)
+		assert.Equal(t, http.MethodPatch, r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		expectedPayload := `{
+			"data": {
+				"id": "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1",
+				"type": "letters",
+				"relationships": {
+					"preset": {
+						"data": {"id": "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "type": "presets"}
+					}
+				}
+			}
+		}`
+		assert.JSONEq(t, expectedPayload, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockResponse))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	resp, err := letterClient.ApplyPreset("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", resp.Data.ID)
+}
+
+func TestCreatePresetFrom(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveLetterDetails(w, r) {
+			return
+		}
+
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/create-preset", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"data":{"type":"presets","attributes":{"name":"Invoices"}}}`, string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"data": {"id": "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "type": "presets", "attributes": {"name": "Invoices"}}}`))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	resp, err := letterClient.CreatePresetFrom("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "Invoices")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", resp.Data.ID)
+	assert.Equal(t, "Invoices", resp.Data.Attributes.Name)
+}
+
+func TestCreatePresetFrom_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	_, err := letterClient.CreatePresetFrom("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "Invoices")
+
+	assert.NotNil(t, err)
+	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
+	assert.Equal(t, expectedMessage, err.Error())
+}
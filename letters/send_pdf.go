@@ -0,0 +1,95 @@
+package letters
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+type SendPDFOptions struct {
+	FileOriginalName string
+	AddressPosition  string
+	DeliveryProduct  string
+	PrintMode        string
+	PrintSpectrum    string
+	SenderAddress    string
+	MetaData         map[string]interface{}
+	Relationships    map[string]interface{}
+	// PollInterval is the delay between status checks while the letter validates. Defaults to 2s.
+	PollInterval time.Duration
+	// ValidationTimeout bounds the wait for validation. Defaults to 5 minutes.
+	ValidationTimeout time.Duration
+	// DeleteOnFailure deletes the letter when validation leaves it unsendable,
+	// so no half-finished letters pile up in the organisation.
+	DeleteOnFailure bool
+}
+
+// SendPDF uploads the file, creates a letter from it, waits until Pingen has validated it
+// and sends it with the given options. It returns the sent letter, including its price.
+// When the letter cannot be sent, the error carries its status and the letter is returned as it was last seen.
+func (l *Letters) SendPDF(pathToFile string, options SendPDFOptions) (LetterResponse, *errors.PingenError) {
+	if options.PollInterval <= 0 {
+		options.PollInterval = 2 * time.Second
+	}
+
+	if options.ValidationTimeout <= 0 {
+		options.ValidationTimeout = 5 * time.Minute
+	}
+
+	letter, err := l.UploadAndCreate(
+		pathToFile,
+		options.FileOriginalName,
+		options.AddressPosition,
+		false,
+		options.DeliveryProduct,
+		options.PrintMode,
+		options.PrintSpectrum,
+		options.SenderAddress,
+		options.MetaData,
+		options.Relationships,
+	)
+	if err != nil {
+		return LetterResponse{}, err
+	}
+
+	letterID := letter.Data.ID
+
+	// Dry-run letters only exist in the log, so they go straight to Send.
+	if !api.IsDryRunID(letterID) {
+		letter, err = l.WaitUntilValidated(letterID, options.PollInterval, options.ValidationTimeout)
+		if err != nil {
+			return letter, err
+		}
+
+		if !letter.CanSend() {
+			deleted := false
+			if options.DeleteOnFailure {
+				_, deleteErr := l.Delete(letterID)
+				deleted = deleteErr == nil
+			}
+
+			return letter, newNotSendableError(letterID, letter.Status(), deleted)
+		}
+	}
+
+	return l.Send(letterID, options.DeliveryProduct, options.PrintMode, options.PrintSpectrum)
+}
+
+func newNotSendableError(letterID string, status Status, deleted bool) *errors.PingenError {
+	body, _ := json.Marshal(map[string]interface{}{
+		"letter_id": letterID,
+		"status":    string(status),
+		"deleted":   deleted,
+	})
+
+	return errors.NewPingenError(
+		fmt.Sprintf("Letter cannot be sent after validation, status %q", status),
+		string(body),
+		http.StatusUnprocessableEntity,
+		nil,
+	)
+}
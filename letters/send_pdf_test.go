@@ -0,0 +1,165 @@
+package letters_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+type sendPDFServer struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	statuses []string
+	requests []string
+}
+
+func newSendPDFServer(t *testing.T, statuses ...string) *sendPDFServer {
+	s := &sendPDFServer{statuses: statuses}
+
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.requests = append(s.requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1"))
+
+		switch {
+		case r.URL.Path == "/file-upload":
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `{"data": {"attributes": {"url": "%s/upload", "url_signature": "signature"}}}`, s.server.URL)
+		case r.URL.Path == "/upload":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"auto_send":false`)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(s.letter("validating")))
+		case r.Method == http.MethodGet:
+			status := s.statuses[0]
+			if len(s.statuses) > 1 {
+				s.statuses = s.statuses[1:]
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(s.letter(status)))
+		case r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"delivery_product":"fast"`)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(s.letter("submitted")))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	return s
+}
+
+func (s *sendPDFServer) letter(status string) string {
+	ability := "state"
+	if status == "valid" {
+		ability = "ok"
+	}
+
+	deletable := "ok"
+	if status == "validating" {
+		deletable = "state"
+	}
+
+	return fmt.Sprintf(`{"data": {"id": "letter-1", "type": "letters", "attributes": {"status": "%s", "price_currency": "CHF", "price_value": 1.25}, "meta": {"abilities": {"self": {"submit": "%s", "delete": "%s"}}}}}`, status, ability, deletable)
+}
+
+func sendPDFOptions() letters.SendPDFOptions {
+	return letters.SendPDFOptions{
+		FileOriginalName:  "invoice.pdf",
+		AddressPosition:   "left",
+		DeliveryProduct:   "fast",
+		PrintMode:         "simplex",
+		PrintSpectrum:     "color",
+		PollInterval:      time.Millisecond,
+		ValidationTimeout: time.Second,
+	}
+}
+
+func TestSendPDF(t *testing.T) {
+	s := newSendPDFServer(t, "validating", "valid")
+	defer s.server.Close()
+
+	letterClient := setupLetter(s.server.URL)
+
+	letter, err := letterClient.SendPDF("testFile.pdf", sendPDFOptions())
+
+	assert.Nil(t, err)
+	assert.Equal(t, letters.StatusSubmitted, letter.Status())
+	assert.Equal(t, 1.25, letter.Data.Attributes.PriceValue)
+	assert.Equal(t, []string{
+		"GET /file-upload",
+		"PUT /upload",
+		"POST /letters",
+		"GET /letters/letter-1",
+		"GET /letters/letter-1",
+		"GET /letters/letter-1",
+		"PATCH /letters/letter-1/send",
+	}, s.requests)
+}
+
+func TestSendPDF_ActionRequired(t *testing.T) {
+	s := newSendPDFServer(t, "action_required")
+	defer s.server.Close()
+
+	letterClient := setupLetter(s.server.URL)
+
+	letter, err := letterClient.SendPDF("testFile.pdf", sendPDFOptions())
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, letters.StatusActionRequired, letter.Status())
+	assert.Equal(t, map[string]interface{}{
+		"letter_id": "letter-1",
+		"status":    "action_required",
+		"deleted":   false,
+	}, err.JSONBody)
+	assert.NotContains(t, s.requests, "PATCH /letters/letter-1/send")
+	assert.NotContains(t, s.requests, "DELETE /letters/letter-1")
+}
+
+func TestSendPDF_DeleteOnFailure(t *testing.T) {
+	s := newSendPDFServer(t, "invalid")
+	defer s.server.Close()
+
+	letterClient := setupLetter(s.server.URL)
+
+	options := sendPDFOptions()
+	options.DeleteOnFailure = true
+
+	_, err := letterClient.SendPDF("testFile.pdf", options)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, true, err.JSONBody.(map[string]interface{})["deleted"])
+	assert.Contains(t, s.requests, "DELETE /letters/letter-1")
+}
+
+func TestSendPDF_ValidationTimeout(t *testing.T) {
+	s := newSendPDFServer(t, "validating")
+	defer s.server.Close()
+
+	letterClient := setupLetter(s.server.URL)
+
+	options := sendPDFOptions()
+	options.DeleteOnFailure = true
+	options.ValidationTimeout = 10 * time.Millisecond
+
+	letter, err := letterClient.SendPDF("testFile.pdf", options)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusRequestTimeout, err.StatusCode)
+	assert.Equal(t, letters.StatusValidating, letter.Status())
+	assert.NotContains(t, s.requests, "DELETE /letters/letter-1")
+}
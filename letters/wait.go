@@ -0,0 +1,37 @@
+package letters
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+// WaitUntilValidated polls the letter until Pingen has finished validating it
+// and returns the letter in whatever status validation produced.
+func (l *Letters) WaitUntilValidated(letterID string, pollInterval, timeout time.Duration) (LetterResponse, *errors.PingenError) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		letter, err := l.GetDetails(letterID, nil, nil)
+		if err != nil {
+			return LetterResponse{}, err
+		}
+
+		if letter.Status() != StatusValidating {
+			return letter, nil
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return letter, errors.NewPingenError(
+				fmt.Sprintf("Letter still validating after %s", timeout),
+				"",
+				http.StatusRequestTimeout,
+				nil,
+			)
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
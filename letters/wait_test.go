@@ -0,0 +1,52 @@
+package letters_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitUntilValidated(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		if requests < 3 {
+			_, _ = w.Write([]byte(strings.Replace(mockResponse, `"status": "send"`, `"status": "validating"`, 1)))
+			return
+		}
+		_, _ = w.Write([]byte(grantedLetterResponse))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	letter, err := letterClient.WaitUntilValidated("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", time.Millisecond, time.Second)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, letters.StatusValid, letter.Status())
+}
+
+func TestWaitUntilValidated_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(strings.Replace(mockResponse, `"status": "send"`, `"status": "validating"`, 1)))
+	}))
+	defer server.Close()
+
+	letterClient := setupLetter(server.URL)
+
+	letter, err := letterClient.WaitUntilValidated("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", 5*time.Millisecond, 20*time.Millisecond)
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusRequestTimeout, err.StatusCode)
+	assert.Equal(t, letters.StatusValidating, letter.Status())
+}
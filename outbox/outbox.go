@@ -0,0 +1,708 @@
+package outbox
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/batches"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/fileupload"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/pingencom/pingen2-sdk-go/response"
+)
+
+type Kind string
+
+const (
+	KindLetter Kind = "letter"
+	KindBatch  Kind = "batch"
+)
+
+// Step is the last step of a submission that is known to have completed.
+// StepCreating and StepSending are recorded before the request is made, so after a
+// crash they mark requests whose outcome is unknown.
+type Step string
+
+const (
+	StepPending  Step = "pending"
+	StepUploaded Step = "uploaded"
+	StepCreating Step = "creating"
+	StepCreated  Step = "created"
+	StepSending  Step = "sending"
+	StepSent     Step = "sent"
+	StepFailed   Step = "failed"
+)
+
+type LetterJob struct {
+	PathToFile       string                 `json:"path_to_file"`
+	FileOriginalName string                 `json:"file_original_name"`
+	AddressPosition  string                 `json:"address_position"`
+	DeliveryProduct  string                 `json:"delivery_product"`
+	PrintMode        string                 `json:"print_mode"`
+	PrintSpectrum    string                 `json:"print_spectrum"`
+	SenderAddress    string                 `json:"sender_address,omitempty"`
+	MetaData         map[string]interface{} `json:"meta_data,omitempty"`
+	Relationships    map[string]interface{} `json:"relationships,omitempty"`
+}
+
+type BatchJob struct {
+	PathToFile       string                  `json:"path_to_file"`
+	Name             string                  `json:"name"`
+	Icon             batches.Icon            `json:"icon"`
+	FileOriginalName string                  `json:"file_original_name"`
+	AddressPosition  batches.AddressPosition `json:"address_position"`
+	GroupingType     batches.GroupingType    `json:"grouping_type"`
+	SplitType        batches.SplitType       `json:"split_type"`
+	SplitSize        *int                    `json:"split_size,omitempty"`
+	SplitSeparator   *string                 `json:"split_separator,omitempty"`
+	SplitPosition    *batches.SplitPosition  `json:"split_position,omitempty"`
+	DeliveryProducts map[string]string       `json:"delivery_products"`
+	PrintMode        string                  `json:"print_mode"`
+	PrintSpectrum    string                  `json:"print_spectrum"`
+}
+
+type Entry struct {
+	ID               string     `json:"id"`
+	Kind             Kind       `json:"kind"`
+	Step             Step       `json:"step"`
+	Letter           *LetterJob `json:"letter,omitempty"`
+	Batch            *BatchJob  `json:"batch,omitempty"`
+	FileURL          string     `json:"file_url,omitempty"`
+	FileURLSignature string     `json:"file_url_signature,omitempty"`
+	FileURLExpiresAt string     `json:"file_url_expires_at,omitempty"`
+	ResourceID       string     `json:"resource_id,omitempty"`
+	Error            string     `json:"error,omitempty"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (e Entry) IsComplete() bool {
+	return e.Step == StepSent || e.Step == StepFailed
+}
+
+type Result struct {
+	Entry Entry
+	Err   *errors.PingenError
+}
+
+type Options struct {
+	// PollInterval is the delay between status checks while waiting for validation. Defaults to 2s.
+	PollInterval time.Duration
+	// ValidationTimeout bounds the wait between create and send. Defaults to 5 minutes.
+	ValidationTimeout time.Duration
+}
+
+// Outbox journals every step of letter and batch submissions to a local file,
+// so that an interrupted submission can be resumed without duplicating or losing it.
+type Outbox struct {
+	file         *os.File
+	letterClient *letters.Letters
+	batchClient  *batches.Batches
+	fileUpload   *fileupload.FileUpload
+	options      Options
+
+	mu      sync.Mutex
+	entries map[string]Entry
+	order   []string
+	// locks serialize the processing of each entry, so concurrent calls cannot repeat a step.
+	locks map[string]*sync.Mutex
+}
+
+func Open(
+	path string,
+	organisationID string,
+	apiRequestor *api.APIRequestor,
+	options Options,
+) (*Outbox, *errors.PingenError) {
+	if options.PollInterval <= 0 {
+		options.PollInterval = 2 * time.Second
+	}
+
+	if options.ValidationTimeout <= 0 {
+		options.ValidationTimeout = 5 * time.Minute
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, newJournalError("Failed to open outbox journal", err)
+	}
+
+	o := &Outbox{
+		file:         file,
+		letterClient: letters.NewLetters(organisationID, apiRequestor),
+		batchClient:  batches.NewBatches(organisationID, apiRequestor),
+		fileUpload:   fileupload.NewFileUpload(apiRequestor),
+		options:      options,
+		entries:      map[string]Entry{},
+		locks:        map[string]*sync.Mutex{},
+	}
+
+	if pErr := o.load(); pErr != nil {
+		file.Close()
+		return nil, pErr
+	}
+
+	return o, nil
+}
+
+func (o *Outbox) Close() error {
+	return o.file.Close()
+}
+
+func (o *Outbox) EnqueueLetter(id string, job LetterJob) *errors.PingenError {
+	return o.enqueue(Entry{ID: id, Kind: KindLetter, Step: StepPending, Letter: &job})
+}
+
+func (o *Outbox) EnqueueBatch(id string, job BatchJob) *errors.PingenError {
+	return o.enqueue(Entry{ID: id, Kind: KindBatch, Step: StepPending, Batch: &job})
+}
+
+func (o *Outbox) Get(id string) (Entry, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entry, ok := o.entries[id]
+	return entry, ok
+}
+
+// Pending returns the incomplete entries in the order they were enqueued.
+func (o *Outbox) Pending() []Entry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var pending []Entry
+	for _, id := range o.order {
+		if entry := o.entries[id]; !entry.IsComplete() {
+			pending = append(pending, entry)
+		}
+	}
+
+	return pending
+}
+
+// Resume drives every incomplete entry as far as it can go, in enqueue order.
+func (o *Outbox) Resume() []Result {
+	var results []Result
+
+	for _, entry := range o.Pending() {
+		processed, err := o.Process(entry.ID)
+		results = append(results, Result{Entry: processed, Err: err})
+	}
+
+	return results
+}
+
+// Process drives a single entry from its last journaled step to completion. Calls for the
+// same entry wait for each other, so a step is never taken twice.
+func (o *Outbox) Process(id string) (Entry, *errors.PingenError) {
+	defer o.lockEntry(id)()
+
+	entry, ok := o.Get(id)
+	if !ok {
+		return Entry{}, errors.NewPingenError(
+			fmt.Sprintf("Unknown outbox entry %q", id),
+			"",
+			http.StatusNotFound,
+			nil,
+		)
+	}
+
+	// An upload journaled before an interruption may have expired in the meantime.
+	if entry.Step == StepUploaded && entry.uploadExpired() {
+		entry.FileURL, entry.FileURLSignature, entry.FileURLExpiresAt = "", "", ""
+		entry = o.advance(entry, StepPending)
+	}
+
+	for !entry.IsComplete() {
+		var err *errors.PingenError
+
+		switch entry.Step {
+		case StepPending:
+			entry, err = o.upload(entry)
+		case StepUploaded:
+			entry, err = o.create(entry)
+		case StepCreating:
+			return entry, newUncertainError(entry)
+		case StepCreated:
+			entry, err = o.send(entry)
+		case StepSending:
+			entry, err = o.reconcileSend(entry)
+		}
+
+		if err != nil {
+			return entry, err
+		}
+	}
+
+	if entry.Step == StepFailed {
+		return entry, errors.NewPingenError(entry.Error, "", http.StatusUnprocessableEntity, nil)
+	}
+
+	return entry, nil
+}
+
+// MarkCreated resolves an entry left in StepCreating once the caller has found
+// the letter or batch that was created for it.
+func (o *Outbox) MarkCreated(id, resourceID string) *errors.PingenError {
+	return o.resolve(id, func(entry Entry) Entry {
+		entry.ResourceID = resourceID
+		return o.advance(entry, StepCreated)
+	})
+}
+
+// Retry resolves an entry left in StepCreating once the caller has confirmed
+// that nothing was created, so the create request is made again.
+func (o *Outbox) Retry(id string) *errors.PingenError {
+	return o.resolve(id, func(entry Entry) Entry {
+		return o.advance(entry, StepUploaded)
+	})
+}
+
+// Compact rewrites the journal keeping only incomplete entries.
+func (o *Outbox) Compact() *errors.PingenError {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var buf bytes.Buffer
+	var order, completed []string
+
+	for _, id := range o.order {
+		entry := o.entries[id]
+		if entry.IsComplete() {
+			completed = append(completed, id)
+			continue
+		}
+
+		line, _ := json.Marshal(entry)
+		buf.Write(line)
+		buf.WriteByte('\n')
+		order = append(order, id)
+	}
+
+	path := o.file.Name()
+	tmpPath := path + ".compact"
+
+	if err := os.WriteFile(tmpPath, buf.Bytes(), 0o600); err != nil {
+		return newJournalError("Failed to compact outbox journal", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return newJournalError("Failed to compact outbox journal", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return newJournalError("Failed to reopen outbox journal", err)
+	}
+
+	o.file.Close()
+	o.file = file
+	o.order = order
+
+	for _, id := range completed {
+		delete(o.entries, id)
+	}
+
+	return nil
+}
+
+func (o *Outbox) upload(entry Entry) (Entry, *errors.PingenError) {
+	fileResponse, err := o.fileUpload.RequestFileUpload()
+	if err != nil {
+		return entry, err
+	}
+
+	err = o.fileUpload.PutFile(entry.pathToFile(), fileResponse.Data.Attributes.URL)
+	if err != nil {
+		return entry, err
+	}
+
+	entry.FileURL = fileResponse.Data.Attributes.URL
+	entry.FileURLSignature = fileResponse.Data.Attributes.URLSignature
+	entry.FileURLExpiresAt = fileResponse.Data.Attributes.ExpiresAt
+
+	return o.record(o.advance(entry, StepUploaded))
+}
+
+func (o *Outbox) create(entry Entry) (Entry, *errors.PingenError) {
+	entry, err := o.record(o.advance(entry, StepCreating))
+	if err != nil {
+		return entry, err
+	}
+
+	var resourceID string
+	var apiErr *errors.PingenError
+
+	switch entry.Kind {
+	case KindLetter:
+		job := entry.Letter
+		var letter letters.LetterResponse
+		letter, apiErr = o.letterClient.Create(
+			entry.FileURL,
+			entry.FileURLSignature,
+			job.FileOriginalName,
+			job.AddressPosition,
+			false,
+			job.DeliveryProduct,
+			job.PrintMode,
+			job.PrintSpectrum,
+			job.SenderAddress,
+			job.MetaData,
+			job.Relationships,
+		)
+		resourceID = letter.Data.ID
+	case KindBatch:
+		job := entry.Batch
+		var batch batches.BatchResponse
+		batch, apiErr = o.batchClient.CreateBatch(
+			entry.FileURL,
+			entry.FileURLSignature,
+			job.Name,
+			job.Icon,
+			job.FileOriginalName,
+			job.AddressPosition,
+			job.GroupingType,
+			job.SplitType,
+			job.SplitSize,
+			job.SplitSeparator,
+			job.SplitPosition,
+		)
+		resourceID = batch.Data.ID
+	}
+
+	if apiErr != nil {
+		if errors.IsRejection(apiErr) {
+			return o.fail(entry, apiErr)
+		}
+		return entry, apiErr
+	}
+
+	entry.ResourceID = resourceID
+	return o.record(o.advance(entry, StepCreated))
+}
+
+func (o *Outbox) send(entry Entry) (Entry, *errors.PingenError) {
+	sendable, reason, err := o.waitUntilSendable(entry)
+	if err != nil {
+		return entry, err
+	}
+
+	if !sendable {
+		entry.Error = reason
+		return o.record(o.advance(entry, StepFailed))
+	}
+
+	entry, err = o.record(o.advance(entry, StepSending))
+	if err != nil {
+		return entry, err
+	}
+
+	return o.submit(entry)
+}
+
+// reconcileSend checks whether a send that was interrupted reached Pingen before repeating it.
+func (o *Outbox) reconcileSend(entry Entry) (Entry, *errors.PingenError) {
+	switch entry.Kind {
+	case KindLetter:
+		letter, err := o.letterClient.GetDetails(entry.ResourceID, nil, nil)
+		if err != nil {
+			return entry, err
+		}
+
+		if letter.CanSend() {
+			return o.submit(entry)
+		}
+
+		if letterWasSubmitted(letter.Status()) {
+			return o.record(o.advance(entry, StepSent))
+		}
+
+		entry.Error = fmt.Sprintf("letter is in status %q", letter.Status())
+	case KindBatch:
+		batch, err := o.batchClient.GetDetails(entry.ResourceID, nil, nil)
+		if err != nil {
+			return entry, err
+		}
+
+		if batch.Data.Meta.Abilities.Self.Submit == response.AbilityOK {
+			return o.submit(entry)
+		}
+
+		status := batch.Data.Attributes.Status
+		if letterWasSubmitted(letters.Status(status)) {
+			return o.record(o.advance(entry, StepSent))
+		}
+
+		entry.Error = fmt.Sprintf("batch is in status %q", status)
+	}
+
+	return o.record(o.advance(entry, StepFailed))
+}
+
+func (o *Outbox) submit(entry Entry) (Entry, *errors.PingenError) {
+	var err *errors.PingenError
+
+	switch entry.Kind {
+	case KindLetter:
+		job := entry.Letter
+		_, err = o.letterClient.Send(entry.ResourceID, job.DeliveryProduct, job.PrintMode, job.PrintSpectrum)
+	case KindBatch:
+		job := entry.Batch
+		_, err = o.batchClient.SendBatch(entry.ResourceID, job.DeliveryProducts, job.PrintMode, job.PrintSpectrum)
+	}
+
+	if err != nil {
+		if errors.IsRejection(err) {
+			return o.fail(entry, err)
+		}
+		return entry, err
+	}
+
+	return o.record(o.advance(entry, StepSent))
+}
+
+func (o *Outbox) waitUntilSendable(entry Entry) (bool, string, *errors.PingenError) {
+	if entry.Kind == KindLetter {
+		letter, err := o.letterClient.WaitUntilValidated(entry.ResourceID, o.options.PollInterval, o.options.ValidationTimeout)
+		if err != nil {
+			return false, "", err
+		}
+
+		return letter.CanSend(), fmt.Sprintf("letter is in status %q", letter.Status()), nil
+	}
+
+	deadline := time.Now().Add(o.options.ValidationTimeout)
+
+	for {
+		batch, err := o.batchClient.GetDetails(entry.ResourceID, nil, nil)
+		if err != nil {
+			return false, "", err
+		}
+
+		status := batch.Data.Attributes.Status
+		if status != "validating" && status != "processing" {
+			return batch.Data.Meta.Abilities.Self.Submit == response.AbilityOK, fmt.Sprintf("batch is in status %q", status), nil
+		}
+
+		if time.Now().Add(o.options.PollInterval).After(deadline) {
+			return false, "", errors.NewPingenError(
+				fmt.Sprintf("Batch still %s after %s", status, o.options.ValidationTimeout),
+				"",
+				http.StatusRequestTimeout,
+				nil,
+			)
+		}
+
+		time.Sleep(o.options.PollInterval)
+	}
+}
+
+func (o *Outbox) fail(entry Entry, err *errors.PingenError) (Entry, *errors.PingenError) {
+	entry.Error = err.Error()
+	entry, recordErr := o.record(o.advance(entry, StepFailed))
+	if recordErr != nil {
+		return entry, recordErr
+	}
+
+	return entry, err
+}
+
+func (o *Outbox) advance(entry Entry, step Step) Entry {
+	entry.Step = step
+	entry.UpdatedAt = time.Now().UTC()
+	return entry
+}
+
+func (o *Outbox) enqueue(entry Entry) *errors.PingenError {
+	o.mu.Lock()
+	_, exists := o.entries[entry.ID]
+	o.mu.Unlock()
+
+	if exists {
+		return errors.NewPingenError(
+			fmt.Sprintf("Outbox entry %q already exists", entry.ID),
+			"",
+			http.StatusConflict,
+			nil,
+		)
+	}
+
+	_, err := o.record(o.advance(entry, StepPending))
+	return err
+}
+
+func (o *Outbox) resolve(id string, apply func(Entry) Entry) *errors.PingenError {
+	defer o.lockEntry(id)()
+
+	entry, ok := o.Get(id)
+	if !ok || entry.Step != StepCreating {
+		return errors.NewPingenError(
+			fmt.Sprintf("Outbox entry %q is not awaiting resolution", id),
+			"",
+			http.StatusConflict,
+			nil,
+		)
+	}
+
+	_, err := o.record(apply(entry))
+	return err
+}
+
+// lockEntry locks the entry's processing and returns the function unlocking it.
+func (o *Outbox) lockEntry(id string) func() {
+	o.mu.Lock()
+	lock, ok := o.locks[id]
+	if !ok {
+		lock = &sync.Mutex{}
+		o.locks[id] = lock
+	}
+	o.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// record appends the entry to the journal and syncs it to disk before the in-memory
+// state is updated, so nothing is acted upon that would not survive a crash.
+func (o *Outbox) record(entry Entry) (Entry, *errors.PingenError) {
+	line, _ := json.Marshal(entry)
+	line = append(line, '\n')
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, err := o.file.Write(line); err != nil {
+		return entry, newJournalError("Failed to write outbox journal", err)
+	}
+
+	if err := o.file.Sync(); err != nil {
+		return entry, newJournalError("Failed to sync outbox journal", err)
+	}
+
+	if _, exists := o.entries[entry.ID]; !exists {
+		o.order = append(o.order, entry.ID)
+	}
+	o.entries[entry.ID] = entry
+
+	return entry, nil
+}
+
+func (o *Outbox) load() *errors.PingenError {
+	reader := bufio.NewReader(o.file)
+
+	var offset, tornAt int64
+	var pendingErr error
+
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return newJournalError("Failed to read outbox journal", readErr)
+		}
+
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			// A line that fails to parse is only acceptable as the last one, where a crash
+			// may have cut the write short.
+			if pendingErr != nil {
+				return newJournalError("Corrupt outbox journal", pendingErr)
+			}
+
+			var entry Entry
+			if err := json.Unmarshal(trimmed, &entry); err != nil {
+				pendingErr = err
+				tornAt = offset
+			} else {
+				if _, exists := o.entries[entry.ID]; !exists {
+					o.order = append(o.order, entry.ID)
+				}
+				o.entries[entry.ID] = entry
+			}
+		}
+
+		offset += int64(len(line))
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	// Cut a torn last line off, and end the journal with a line break, so the next record
+	// starts on a line of its own instead of being appended to the broken one.
+	if pendingErr != nil {
+		if err := o.file.Truncate(tornAt); err != nil {
+			return newJournalError("Failed to repair outbox journal", err)
+		}
+		offset = tornAt
+	}
+
+	if offset > 0 {
+		last := make([]byte, 1)
+		if _, err := o.file.ReadAt(last, offset-1); err != nil {
+			return newJournalError("Failed to read outbox journal", err)
+		}
+
+		if last[0] != '\n' {
+			if _, err := o.file.Write([]byte{'\n'}); err != nil {
+				return newJournalError("Failed to repair outbox journal", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e Entry) pathToFile() string {
+	if e.Kind == KindBatch {
+		return e.Batch.PathToFile
+	}
+
+	return e.Letter.PathToFile
+}
+
+func (e Entry) uploadExpired() bool {
+	if e.FileURLExpiresAt == "" {
+		return false
+	}
+
+	expiresAt, err := time.Parse("2006-01-02T15:04:05-0700", e.FileURLExpiresAt)
+	if err != nil {
+		return false
+	}
+
+	return time.Now().After(expiresAt)
+}
+
+// letterWasSubmitted also serves batches, which report the statuses of their letters once submitted.
+func letterWasSubmitted(status letters.Status) bool {
+	switch status {
+	case letters.StatusSubmitted, letters.StatusAccepted, letters.StatusPrinting, letters.StatusSent, letters.StatusUndeliverable:
+		return true
+	default:
+		return false
+	}
+}
+
+func newUncertainError(entry Entry) *errors.PingenError {
+	return errors.NewPingenError(
+		fmt.Sprintf("Outcome of creating %s %q is unknown; resolve it with MarkCreated or Retry", entry.Kind, entry.ID),
+		"",
+		http.StatusConflict,
+		nil,
+	)
+}
+
+func newJournalError(message string, err error) *errors.PingenError {
+	return errors.NewPingenError(
+		fmt.Sprintf("%s: %v", message, err),
+		"",
+		http.StatusInternalServerError,
+		nil,
+	)
+}
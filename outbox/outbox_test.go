@@ -0,0 +1,519 @@
+package outbox_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/batches"
+	"github.com/pingencom/pingen2-sdk-go/outbox"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAPI struct {
+	server *httptest.Server
+
+	mu            sync.Mutex
+	status        string
+	createReplies []int
+	sendReplies   []int
+	uploads       int
+	creates       int
+	sends         int
+}
+
+func newMockAPI(t *testing.T) *mockAPI {
+	m := &mockAPI{status: "validating"}
+
+	m.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		expiresAt := time.Now().Add(time.Hour).Format("2006-01-02T15:04:05-0700")
+
+		switch {
+		case r.URL.Path == "/file-upload":
+			w.WriteHeader(http.StatusOK)
+			_, _ = fmt.Fprintf(w, `{"data": {"attributes": {"url": "%s/upload", "url_signature": "mock-signature", "expires_at": "%s"}}}`, m.server.URL, expiresAt)
+		case r.URL.Path == "/upload":
+			m.uploads++
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost:
+			m.creates++
+			if reply := next(&m.createReplies); reply != 0 {
+				w.WriteHeader(reply)
+				_, _ = w.Write([]byte(`{"errors": [{"detail": "mock failure"}]}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(m.resource(r.URL.Path)))
+		case r.Method == http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(m.resource(r.URL.Path)))
+			if m.status == "validating" {
+				m.status = "valid"
+			}
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/send"):
+			m.sends++
+			if reply := next(&m.sendReplies); reply != 0 {
+				w.WriteHeader(reply)
+				_, _ = w.Write([]byte(`{"errors": [{"detail": "mock failure"}]}`))
+				return
+			}
+			m.status = "submitted"
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(m.resource(r.URL.Path)))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+
+	return m
+}
+
+func (m *mockAPI) resource(path string) string {
+	submit := "state"
+	if m.status == "valid" {
+		submit = "ok"
+	}
+
+	if strings.Contains(path, "/batches") {
+		return fmt.Sprintf(`{"data": {"id": "batch-1", "type": "batches", "attributes": {"status": "%s"}, "meta": {"abilities": {"self": {"submit": "%s"}}}}}`, m.status, submit)
+	}
+
+	return fmt.Sprintf(`{"data": {"id": "letter-1", "type": "letters", "attributes": {"status": "%s"}, "meta": {"abilities": {"self": {"submit": "%s"}}}}}`, m.status, submit)
+}
+
+func (m *mockAPI) counts() (int, int, int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.uploads, m.creates, m.sends
+}
+
+func next(replies *[]int) int {
+	if len(*replies) == 0 {
+		return 0
+	}
+
+	reply := (*replies)[0]
+	*replies = (*replies)[1:]
+	return reply
+}
+
+func openOutbox(t *testing.T, apiBaseURL, path string) *outbox.Outbox {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(apiBaseURL)
+	apiRequestor := api.NewAPIRequestor("dummyToken", config)
+
+	box, err := outbox.Open(path, "testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", apiRequestor, outbox.Options{
+		PollInterval:      time.Millisecond,
+		ValidationTimeout: time.Second,
+	})
+	assert.Nil(t, err)
+
+	return box
+}
+
+func newLetterJob() outbox.LetterJob {
+	return outbox.LetterJob{
+		PathToFile:       "testFile.pdf",
+		FileOriginalName: "invoice.pdf",
+		AddressPosition:  "left",
+		DeliveryProduct:  "cheap",
+		PrintMode:        "simplex",
+		PrintSpectrum:    "grayscale",
+	}
+}
+
+func newBatchJob() outbox.BatchJob {
+	return outbox.BatchJob{
+		PathToFile:       "testFile.pdf",
+		Name:             "Invoices",
+		Icon:             batches.IconCampaign,
+		FileOriginalName: "invoices.pdf",
+		AddressPosition:  batches.AddressPositionLeft,
+		GroupingType:     batches.GroupingTypeMerge,
+		SplitType:        batches.SplitTypePage,
+		DeliveryProducts: map[string]string{"ch": "cheap"},
+		PrintMode:        "simplex",
+		PrintSpectrum:    "grayscale",
+	}
+}
+
+func TestOutbox_ProcessLetter(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	box := openOutbox(t, mock.server.URL, path)
+
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+
+	entry, err := box.Process("job-1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, outbox.StepSent, entry.Step)
+	assert.Equal(t, "letter-1", entry.ResourceID)
+	assert.Empty(t, box.Pending())
+
+	uploads, creates, sends := mock.counts()
+	assert.Equal(t, 1, uploads)
+	assert.Equal(t, 1, creates)
+	assert.Equal(t, 1, sends)
+
+	assert.Nil(t, box.Close())
+
+	reopened := openOutbox(t, mock.server.URL, path)
+	defer reopened.Close()
+
+	stored, ok := reopened.Get("job-1")
+	assert.True(t, ok)
+	assert.Equal(t, outbox.StepSent, stored.Step)
+}
+
+func TestOutbox_EnqueueDuplicate(t *testing.T) {
+	box := openOutbox(t, "http://localhost", filepath.Join(t.TempDir(), "outbox.jsonl"))
+	defer box.Close()
+
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+
+	err := box.EnqueueLetter("job-1", newLetterJob())
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+}
+
+func TestOutbox_ResumeRepeatsSendThatDidNotArrive(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.sendReplies = []int{http.StatusServiceUnavailable}
+	defer mock.server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	box := openOutbox(t, mock.server.URL, path)
+
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+
+	entry, err := box.Process("job-1")
+	assert.NotNil(t, err)
+	assert.Equal(t, outbox.StepSending, entry.Step)
+	assert.Nil(t, box.Close())
+
+	reopened := openOutbox(t, mock.server.URL, path)
+	defer reopened.Close()
+
+	results := reopened.Resume()
+
+	assert.Len(t, results, 1)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, outbox.StepSent, results[0].Entry.Step)
+
+	_, creates, sends := mock.counts()
+	assert.Equal(t, 1, creates)
+	assert.Equal(t, 2, sends)
+}
+
+func TestOutbox_ResumeDoesNotRepeatSendThatArrived(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.sendReplies = []int{http.StatusBadGateway}
+	defer mock.server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	box := openOutbox(t, mock.server.URL, path)
+
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+
+	_, err := box.Process("job-1")
+	assert.NotNil(t, err)
+	assert.Nil(t, box.Close())
+
+	// The gateway failed, but the send went through.
+	mock.mu.Lock()
+	mock.status = "submitted"
+	mock.mu.Unlock()
+
+	reopened := openOutbox(t, mock.server.URL, path)
+	defer reopened.Close()
+
+	entry, err := reopened.Process("job-1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, outbox.StepSent, entry.Step)
+
+	_, _, sends := mock.counts()
+	assert.Equal(t, 1, sends)
+}
+
+func TestOutbox_ResumeFailsBatchThatWasNotSubmitted(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.sendReplies = []int{http.StatusBadGateway}
+	defer mock.server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	box := openOutbox(t, mock.server.URL, path)
+
+	assert.Nil(t, box.EnqueueBatch("batch-job", newBatchJob()))
+
+	entry, err := box.Process("batch-job")
+	assert.NotNil(t, err)
+	assert.Equal(t, outbox.StepSending, entry.Step)
+	assert.Nil(t, box.Close())
+
+	// The batch was cancelled in the meantime, so it can neither be sent nor count as sent.
+	mock.mu.Lock()
+	mock.status = "cancelled"
+	mock.mu.Unlock()
+
+	reopened := openOutbox(t, mock.server.URL, path)
+	defer reopened.Close()
+
+	entry, err = reopened.Process("batch-job")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, outbox.StepFailed, entry.Step)
+	assert.Equal(t, `batch is in status "cancelled"`, entry.Error)
+
+	_, _, sends := mock.counts()
+	assert.Equal(t, 1, sends)
+}
+
+func TestOutbox_UncertainCreate(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.createReplies = []int{http.StatusInternalServerError}
+	defer mock.server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	box := openOutbox(t, mock.server.URL, path)
+	defer box.Close()
+
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+
+	entry, err := box.Process("job-1")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusInternalServerError, err.StatusCode)
+	assert.Equal(t, outbox.StepCreating, entry.Step)
+
+	_, err = box.Process("job-1")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+
+	_, creates, _ := mock.counts()
+	assert.Equal(t, 1, creates)
+
+	assert.Nil(t, box.MarkCreated("job-1", "letter-1"))
+
+	entry, err = box.Process("job-1")
+	assert.Nil(t, err)
+	assert.Equal(t, outbox.StepSent, entry.Step)
+
+	_, creates, sends := mock.counts()
+	assert.Equal(t, 1, creates)
+	assert.Equal(t, 1, sends)
+}
+
+func TestOutbox_RetryUncertainCreate(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.createReplies = []int{http.StatusInternalServerError}
+	defer mock.server.Close()
+
+	box := openOutbox(t, mock.server.URL, filepath.Join(t.TempDir(), "outbox.jsonl"))
+	defer box.Close()
+
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+
+	_, err := box.Process("job-1")
+	assert.NotNil(t, err)
+
+	assert.Nil(t, box.Retry("job-1"))
+
+	entry, err := box.Process("job-1")
+	assert.Nil(t, err)
+	assert.Equal(t, outbox.StepSent, entry.Step)
+
+	uploads, creates, _ := mock.counts()
+	assert.Equal(t, 1, uploads)
+	assert.Equal(t, 2, creates)
+
+	err = box.Retry("job-1")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, err.StatusCode)
+}
+
+func TestOutbox_RejectedCreate(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.createReplies = []int{http.StatusUnprocessableEntity}
+	defer mock.server.Close()
+
+	box := openOutbox(t, mock.server.URL, filepath.Join(t.TempDir(), "outbox.jsonl"))
+	defer box.Close()
+
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+
+	entry, err := box.Process("job-1")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+	assert.Equal(t, outbox.StepFailed, entry.Step)
+	assert.Contains(t, entry.Error, "Status Code: 422")
+	assert.Empty(t, box.Pending())
+}
+
+func TestOutbox_ProcessBatch(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	box := openOutbox(t, mock.server.URL, filepath.Join(t.TempDir(), "outbox.jsonl"))
+	defer box.Close()
+
+	assert.Nil(t, box.EnqueueBatch("batch-job", newBatchJob()))
+
+	entry, err := box.Process("batch-job")
+
+	assert.Nil(t, err)
+	assert.Equal(t, outbox.StepSent, entry.Step)
+	assert.Equal(t, "batch-1", entry.ResourceID)
+}
+
+func TestOutbox_Journal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+
+	box := openOutbox(t, "http://localhost", path)
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+	assert.Nil(t, box.EnqueueLetter("job-2", newLetterJob()))
+	assert.Nil(t, box.Close())
+
+	// A crash while appending leaves a partial last line behind.
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	_, _ = file.WriteString(`{"id": "job-3", "ki`)
+	file.Close()
+
+	box = openOutbox(t, "http://localhost", path)
+	pending := box.Pending()
+	assert.Len(t, pending, 2)
+	assert.Equal(t, "job-1", pending[0].ID)
+	assert.Equal(t, "job-2", pending[1].ID)
+	assert.Nil(t, box.Close())
+
+	contents, _ := os.ReadFile(path)
+	assert.Nil(t, os.WriteFile(path, append([]byte("not json\n"), contents...), 0o600))
+
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	_, err := outbox.Open(path, "org", api.NewAPIRequestor("dummyToken", config), outbox.Options{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Message, "Corrupt outbox journal")
+}
+
+func TestOutbox_Compact(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	box := openOutbox(t, mock.server.URL, path)
+
+	assert.Nil(t, box.EnqueueLetter("done", newLetterJob()))
+	_, err := box.Process("done")
+	assert.Nil(t, err)
+	assert.Nil(t, box.EnqueueLetter("waiting", newLetterJob()))
+
+	assert.Nil(t, box.Compact())
+
+	_, ok := box.Get("done")
+	assert.False(t, ok)
+
+	assert.Nil(t, box.EnqueueLetter("later", newLetterJob()))
+	assert.Nil(t, box.Close())
+
+	contents, _ := os.ReadFile(path)
+	assert.Equal(t, 2, strings.Count(string(contents), "\n"))
+
+	reopened := openOutbox(t, mock.server.URL, path)
+	defer reopened.Close()
+
+	pending := reopened.Pending()
+	assert.Len(t, pending, 2)
+	assert.Equal(t, "waiting", pending[0].ID)
+	assert.Equal(t, "later", pending[1].ID)
+}
+
+func TestOutbox_CompactFailureKeepsEntries(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	box := openOutbox(t, mock.server.URL, path)
+	defer box.Close()
+
+	assert.Nil(t, box.EnqueueLetter("done", newLetterJob()))
+	_, err := box.Process("done")
+	assert.Nil(t, err)
+
+	// A directory in the way of the rewritten journal makes compacting fail.
+	assert.Nil(t, os.Mkdir(path+".compact", 0o700))
+
+	assert.NotNil(t, box.Compact())
+
+	entry, ok := box.Get("done")
+	assert.True(t, ok)
+	assert.Equal(t, outbox.StepSent, entry.Step)
+}
+
+func TestOutbox_WriteAfterTornLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+
+	box := openOutbox(t, "http://localhost", path)
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+	assert.Nil(t, box.Close())
+
+	file, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	_, _ = file.WriteString(`{"id": "job-2", "ki`)
+	file.Close()
+
+	box = openOutbox(t, "http://localhost", path)
+	assert.Nil(t, box.EnqueueLetter("job-3", newLetterJob()))
+	assert.Nil(t, box.EnqueueLetter("job-4", newLetterJob()))
+	assert.Nil(t, box.Close())
+
+	box = openOutbox(t, "http://localhost", path)
+	defer box.Close()
+
+	var ids []string
+	for _, entry := range box.Pending() {
+		ids = append(ids, entry.ID)
+	}
+	assert.Equal(t, []string{"job-1", "job-3", "job-4"}, ids)
+}
+
+func TestOutbox_ConcurrentProcessCreatesOnce(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	box := openOutbox(t, mock.server.URL, filepath.Join(t.TempDir(), "outbox.jsonl"))
+	defer box.Close()
+
+	assert.Nil(t, box.EnqueueLetter("job-1", newLetterJob()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entry, err := box.Process("job-1")
+			assert.Nil(t, err)
+			assert.Equal(t, outbox.StepSent, entry.Step)
+		}()
+	}
+	wg.Wait()
+
+	uploads, creates, sends := mock.counts()
+	assert.Equal(t, 1, uploads)
+	assert.Equal(t, 1, creates)
+	assert.Equal(t, 1, sends)
+}
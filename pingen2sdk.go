@@ -2,6 +2,7 @@ package pingen2sdk
 
 import (
 	"fmt"
+	"log"
 	"time"
 )
 
@@ -10,6 +11,9 @@ type Config struct {
 	clientSecret      string
 	environment       string
 	requestTimeout    time.Duration
+	downloadTimeout   time.Duration
+	dryRun            bool
+	dryRunLogger      *log.Logger
 	apiProductionUrl  string
 	authProductionUrl string
 	apiStagingUrl     string
@@ -26,6 +30,7 @@ func InitSDK(clientID, clientSecret, environment string) (*Config, error) {
 		clientSecret:      clientSecret,
 		environment:       environment,
 		requestTimeout:    20 * time.Second,
+		downloadTimeout:   10 * time.Minute,
 		apiProductionUrl:  "https://api.pingen.com",
 		authProductionUrl: "https://identity.pingen.com",
 		apiStagingUrl:     "https://api-staging.pingen.com",
@@ -69,6 +74,38 @@ func (c *Config) GetRequestTimeout() time.Duration {
 	return c.requestTimeout
 }
 
+// SetDownloadTimeout bounds file downloads, which can take far longer than JSON requests.
+// Defaults to 10 minutes; zero removes the limit, so a stalled download blocks forever.
+func (c *Config) SetDownloadTimeout(timeout time.Duration) {
+	c.downloadTimeout = timeout
+}
+
+func (c *Config) GetDownloadTimeout() time.Duration {
+	return c.downloadTimeout
+}
+
+// SetDryRun makes every mutating API call log its request and return a synthetic response
+// instead of being sent. Read calls still reach the API.
+func (c *Config) SetDryRun(enabled bool) {
+	c.dryRun = enabled
+}
+
+func (c *Config) IsDryRun() bool {
+	return c.dryRun
+}
+
+// SetDryRunLogger sets where skipped requests are logged. Defaults to the standard logger.
+func (c *Config) SetDryRunLogger(logger *log.Logger) {
+	c.dryRunLogger = logger
+}
+
+func (c *Config) GetDryRunLogger() *log.Logger {
+	if c.dryRunLogger == nil {
+		return log.Default()
+	}
+	return c.dryRunLogger
+}
+
 func (c *Config) GetUserAgent() string {
 	return "PINGEN.SDK.GO"
 }
@@ -1,6 +1,8 @@
 package pingen2sdk
 
 import (
+	"io"
+	"log"
 	"testing"
 	"time"
 )
@@ -253,6 +255,55 @@ func TestConfig_GetRequestTimeout(t *testing.T) {
 	})
 }
 
+func TestConfig_DownloadTimeout(t *testing.T) {
+	t.Run("returns default timeout", func(t *testing.T) {
+		config, _ := InitSDK("client", "secret", "production")
+
+		if timeout := config.GetDownloadTimeout(); timeout != 10*time.Minute {
+			t.Errorf("Expected download timeout 10m, got %v", timeout)
+		}
+	})
+
+	t.Run("returns configured timeout", func(t *testing.T) {
+		config, _ := InitSDK("client", "secret", "production")
+		config.SetDownloadTimeout(5 * time.Minute)
+
+		timeout := config.GetDownloadTimeout()
+		expected := 5 * time.Minute
+
+		if timeout != expected {
+			t.Errorf("Expected download timeout %v, got %v", expected, timeout)
+		}
+	})
+}
+
+func TestConfig_DryRun(t *testing.T) {
+	t.Run("is disabled by default", func(t *testing.T) {
+		config, _ := InitSDK("client", "secret", "production")
+
+		if config.IsDryRun() {
+			t.Error("Expected dry run to be disabled")
+		}
+		if config.GetDryRunLogger() != log.Default() {
+			t.Error("Expected the standard logger by default")
+		}
+	})
+
+	t.Run("returns configured settings", func(t *testing.T) {
+		config, _ := InitSDK("client", "secret", "production")
+		logger := log.New(io.Discard, "", 0)
+		config.SetDryRun(true)
+		config.SetDryRunLogger(logger)
+
+		if !config.IsDryRun() {
+			t.Error("Expected dry run to be enabled")
+		}
+		if config.GetDryRunLogger() != logger {
+			t.Error("Expected the configured logger")
+		}
+	})
+}
+
 func TestConfig_GetUserAgent(t *testing.T) {
 	t.Run("returns correct user agent", func(t *testing.T) {
 		config, _ := InitSDK("client", "secret", "production")
@@ -0,0 +1,209 @@
+package presets
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+type Presets struct {
+	organisationID string
+	apiRequestor   *api.APIRequestor
+}
+
+// Settings holds the print, delivery and paper options stored in a preset.
+// Empty fields are left out of the request.
+type Settings struct {
+	AddressPosition string
+	DeliveryProduct string
+	PrintMode       string
+	PrintSpectrum   string
+	PaperTypes      []string
+	SenderAddress   string
+}
+
+type PresetResponseData struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Attributes struct {
+		Name            string   `json:"name"`
+		AddressPosition string   `json:"address_position"`
+		DeliveryProduct string   `json:"delivery_product"`
+		PrintMode       string   `json:"print_mode"`
+		PrintSpectrum   string   `json:"print_spectrum"`
+		PaperTypes      []string `json:"paper_types"`
+		SenderAddress   string   `json:"sender_address"`
+		CreatedAt       string   `json:"created_at"`
+		UpdatedAt       string   `json:"updated_at"`
+	} `json:"attributes"`
+	Relationships struct {
+		Organisation struct {
+			Links struct {
+				Related string `json:"related"`
+			} `json:"links"`
+			Data struct {
+				ID   string `json:"id"`
+				Type string `json:"type"`
+			} `json:"data"`
+		} `json:"organisation"`
+	} `json:"relationships"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"links"`
+}
+
+type PresetResponse struct {
+	Data     PresetResponseData `json:"data"`
+	Included []struct{}         `json:"included"`
+}
+
+type PresetCollectionResponse struct {
+	Data     []PresetResponseData `json:"data"`
+	Included []struct{}           `json:"included"`
+	Links    struct {
+		First string `json:"first"`
+		Last  string `json:"last"`
+		Prev  string `json:"prev"`
+		Next  string `json:"next"`
+		Self  string `json:"self"`
+	} `json:"links"`
+	Meta struct {
+		CurrentPage int `json:"current_page"`
+		LastPage    int `json:"last_page"`
+		PerPage     int `json:"per_page"`
+		From        int `json:"from"`
+		To          int `json:"to"`
+		Total       int `json:"total"`
+	} `json:"meta"`
+}
+
+func NewPresets(organisationID string, apiRequestor *api.APIRequestor) *Presets {
+	return &Presets{
+		organisationID: organisationID,
+		apiRequestor:   apiRequestor,
+	}
+}
+
+func (p *Presets) GetDetails(presetID string, params map[string]string, suppliedHeaders map[string]string) (PresetResponse, *errors.PingenError) {
+	var response PresetResponse
+	url := fmt.Sprintf("/organisations/%s/presets/%s", p.organisationID, presetID)
+
+	_, err := p.apiRequestor.PerformGetRequest(url, &response, params, suppliedHeaders)
+	if err != nil {
+		return PresetResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (p *Presets) GetCollection(params map[string]string, suppliedHeaders map[string]string) (PresetCollectionResponse, *errors.PingenError) {
+	var response PresetCollectionResponse
+	url := fmt.Sprintf("/organisations/%s/presets", p.organisationID)
+
+	_, err := p.apiRequestor.PerformGetRequest(url, &response, params, suppliedHeaders)
+	if err != nil {
+		return PresetCollectionResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (p *Presets) Create(name string, settings Settings) (PresetResponse, *errors.PingenError) {
+	attributes := settings.attributes()
+	attributes["name"] = name
+
+	payload := map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":       "presets",
+			"attributes": attributes,
+		},
+	}
+
+	data, _ := json.Marshal(payload)
+	url := fmt.Sprintf("/organisations/%s/presets", p.organisationID)
+
+	var response PresetResponse
+
+	_, err := p.apiRequestor.PerformPostRequest(url, &response, data, nil)
+	if err != nil {
+		return PresetResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (p *Presets) Update(presetID, name string, settings Settings) (PresetResponse, *errors.PingenError) {
+	attributes := settings.attributes()
+	if name != "" {
+		attributes["name"] = name
+	}
+
+	payload := map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":         presetID,
+			"type":       "presets",
+			"attributes": attributes,
+		},
+	}
+
+	data, _ := json.Marshal(payload)
+	url := fmt.Sprintf("/organisations/%s/presets/%s", p.organisationID, presetID)
+
+	var response PresetResponse
+
+	_, err := p.apiRequestor.PerformPatchRequest(url, &response, data, nil)
+	if err != nil {
+		return PresetResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (p *Presets) Delete(presetID string) (interface{}, *errors.PingenError) {
+	url := fmt.Sprintf("/organisations/%s/presets/%s", p.organisationID, presetID)
+	return p.apiRequestor.PerformDeleteRequest(url)
+}
+
+// Relationship builds the relationships entry that links a letter, ebill or email to a preset.
+func Relationship(presetID string) map[string]interface{} {
+	return map[string]interface{}{
+		"preset": map[string]interface{}{
+			"data": map[string]interface{}{
+				"id":   presetID,
+				"type": "presets",
+			},
+		},
+	}
+}
+
+func (s Settings) attributes() map[string]interface{} {
+	attributes := map[string]interface{}{}
+
+	if s.AddressPosition != "" {
+		attributes["address_position"] = s.AddressPosition
+	}
+
+	if s.DeliveryProduct != "" {
+		attributes["delivery_product"] = s.DeliveryProduct
+	}
+
+	if s.PrintMode != "" {
+		attributes["print_mode"] = s.PrintMode
+	}
+
+	if s.PrintSpectrum != "" {
+		attributes["print_spectrum"] = s.PrintSpectrum
+	}
+
+	if s.PaperTypes != nil {
+		attributes["paper_types"] = s.PaperTypes
+	}
+
+	if s.SenderAddress != "" {
+		attributes["sender_address"] = s.SenderAddress
+	}
+
+	return attributes
+}
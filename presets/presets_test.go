@@ -0,0 +1,267 @@
+package presets_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/presets"
+	"github.com/stretchr/testify/assert"
+)
+
+const mockPresetResponse = `{
+	"data": {
+		"id": "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1",
+		"type": "presets",
+		"attributes": {
+			"name": "Invoices",
+			"address_position": "left",
+			"delivery_product": "cheap",
+			"print_mode": "duplex",
+			"print_spectrum": "grayscale",
+			"paper_types": ["normal", "qr"],
+			"sender_address": "ACME GmbH | Strasse 3 | 8000 Zürich",
+			"created_at": "2020-11-19T09:42:48+0100",
+			"updated_at": "2020-11-19T09:42:48+0100"
+		},
+		"relationships": {
+			"organisation": {
+				"links": {
+					"related": "string"
+				},
+				"data": {
+					"id": "testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1",
+					"type": "organisations"
+				}
+			}
+		},
+		"links": {
+			"self": "string"
+		}
+	},
+	"included": []
+}`
+
+func setupUnauthorizedServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		w.Header().Set("X-Request-Id", "requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		responseJSON := `{"error":"invalid_client","error_description":"Client authentication failed","message":"Client authentication failed"}`
+		_, _ = w.Write([]byte(responseJSON))
+	}))
+}
+
+func setupPresets(apiBaseURL string) *presets.Presets {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(apiBaseURL)
+	apiRequestor := api.NewAPIRequestor("dummyToken", config)
+
+	return presets.NewPresets("testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", apiRequestor)
+}
+
+func TestGetDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/presets/presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockPresetResponse))
+	}))
+	defer server.Close()
+
+	presetClient := setupPresets(server.URL)
+
+	resp, err := presetClient.GetDetails("presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", map[string]string{}, map[string]string{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", resp.Data.ID)
+	assert.Equal(t, "Invoices", resp.Data.Attributes.Name)
+	assert.Equal(t, []string{"normal", "qr"}, resp.Data.Attributes.PaperTypes)
+}
+
+func TestGetDetails_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	presetClient := setupPresets(server.URL)
+
+	_, err := presetClient.GetDetails("presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", nil, nil)
+
+	assert.NotNil(t, err)
+	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
+	assert.Equal(t, expectedMessage, err.Error())
+}
+
+func TestGetCollection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/presets", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"data": [
+				{"id": "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "type": "presets", "attributes": {"name": "Invoices"}},
+				{"id": "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx2", "type": "presets", "attributes": {"name": "Reminders"}}
+			],
+			"included": [],
+			"links": {"first": "first-link", "last": "last-link"},
+			"meta": {"current_page": 1, "last_page": 1, "per_page": 10, "total": 2}
+		}`))
+	}))
+	defer server.Close()
+
+	presetClient := setupPresets(server.URL)
+
+	resp, err := presetClient.GetCollection(map[string]string{}, map[string]string{})
+
+	assert.Nil(t, err)
+	assert.Len(t, resp.Data, 2)
+	assert.Equal(t, "Reminders", resp.Data[1].Attributes.Name)
+	assert.Equal(t, 2, resp.Meta.Total)
+}
+
+func TestGetCollection_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	presetClient := setupPresets(server.URL)
+
+	_, err := presetClient.GetCollection(nil, nil)
+
+	assert.NotNil(t, err)
+	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
+	assert.Equal(t, expectedMessage, err.Error())
+}
+
+func TestCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/presets", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		expectedPayload := `{
+			"data": {
+				"type": "presets",
+				"attributes": {
+					"name": "Invoices",
+					"delivery_product": "cheap",
+					"print_mode": "duplex",
+					"paper_types": ["normal", "qr"]
+				}
+			}
+		}`
+		assert.JSONEq(t, expectedPayload, string(body))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(mockPresetResponse))
+	}))
+	defer server.Close()
+
+	presetClient := setupPresets(server.URL)
+
+	resp, err := presetClient.Create("Invoices", presets.Settings{
+		DeliveryProduct: "cheap",
+		PrintMode:       "duplex",
+		PaperTypes:      []string{"normal", "qr"},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", resp.Data.ID)
+}
+
+func TestCreate_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	presetClient := setupPresets(server.URL)
+
+	_, err := presetClient.Create("Invoices", presets.Settings{})
+
+	assert.NotNil(t, err)
+	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
+	assert.Equal(t, expectedMessage, err.Error())
+}
+
+func TestUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/presets/presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
+		assert.Equal(t, http.MethodPatch, r.Method)
+
+		body, _ := io.ReadAll(r.Body)
+		expectedPayload := `{
+			"data": {
+				"id": "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1",
+				"type": "presets",
+				"attributes": {
+					"address_position": "right",
+					"print_spectrum": "color",
+					"sender_address": "ACME GmbH"
+				}
+			}
+		}`
+		assert.JSONEq(t, expectedPayload, string(body))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(mockPresetResponse))
+	}))
+	defer server.Close()
+
+	presetClient := setupPresets(server.URL)
+
+	resp, err := presetClient.Update("presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "", presets.Settings{
+		AddressPosition: "right",
+		PrintSpectrum:   "color",
+		SenderAddress:   "ACME GmbH",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", resp.Data.ID)
+}
+
+func TestUpdate_Error(t *testing.T) {
+	server := setupUnauthorizedServer()
+	defer server.Close()
+
+	presetClient := setupPresets(server.URL)
+
+	_, err := presetClient.Update("presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", "Renamed", presets.Settings{})
+
+	assert.NotNil(t, err)
+	expectedMessage := "PingenError: API error (Status Code: 401, Request ID: requestx-yyyy-yyyy-yyyy-yyyyyyyyyyy2)"
+	assert.Equal(t, expectedMessage, err.Error())
+}
+
+func TestDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/presets/presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", r.URL.Path)
+		assert.Equal(t, http.MethodDelete, r.Method)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	presetClient := setupPresets(server.URL)
+
+	resp, err := presetClient.Delete("presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1")
+
+	assert.Nil(t, err)
+	assert.NotNil(t, resp)
+}
+
+func TestRelationship(t *testing.T) {
+	expected := map[string]interface{}{
+		"preset": map[string]interface{}{
+			"data": map[string]interface{}{
+				"id":   "presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1",
+				"type": "presets",
+			},
+		},
+	}
+
+	assert.Equal(t, expected, presets.Relationship("presetxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1"))
+}
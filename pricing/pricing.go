@@ -0,0 +1,211 @@
+package pricing
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+)
+
+// Input is one combination of letter settings to price.
+type Input struct {
+	Country         string
+	PaperTypes      []string
+	PrintMode       string
+	PrintSpectrum   string
+	DeliveryProduct string
+}
+
+type Quote struct {
+	Input    Input
+	Currency string
+	Price    float64
+}
+
+type Estimate struct {
+	// Quotes holds one quote per planned letter, in the order they were passed.
+	Quotes []Quote
+	// Totals sums the quotes per currency, rounded to cents.
+	Totals map[string]float64
+}
+
+type Options struct {
+	// TTL is how long a price stays cached. Zero caches for the lifetime of the Quoter.
+	TTL time.Duration
+	// Concurrency limits the price lookups in flight. Defaults to 4.
+	Concurrency int
+}
+
+// Quoter prices letters through letters.CalculatePrice, looking up each distinct input
+// only once while it is cached. It is safe for concurrent use.
+type Quoter struct {
+	letterClient *letters.Letters
+	ttl          time.Duration
+	slots        chan struct{}
+
+	mu       sync.Mutex
+	cache    map[string]cachedQuote
+	inFlight map[string]*lookup
+}
+
+type cachedQuote struct {
+	currency  string
+	price     float64
+	expiresAt time.Time
+}
+
+type lookup struct {
+	done     chan struct{}
+	currency string
+	price    float64
+	err      *errors.PingenError
+}
+
+func NewQuoter(letterClient *letters.Letters, options Options) *Quoter {
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 4
+	}
+
+	return &Quoter{
+		letterClient: letterClient,
+		ttl:          options.TTL,
+		slots:        make(chan struct{}, concurrency),
+		cache:        map[string]cachedQuote{},
+		inFlight:     map[string]*lookup{},
+	}
+}
+
+func (q *Quoter) Quote(input Input) (Quote, *errors.PingenError) {
+	currency, price, err := q.price(input)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	return Quote{Input: input, Currency: currency, Price: price}, nil
+}
+
+// Estimate prices a whole planned send. Distinct inputs are looked up concurrently;
+// the first error in input order is returned.
+func (q *Quoter) Estimate(inputs []Input) (Estimate, *errors.PingenError) {
+	unique := map[string]Input{}
+	for _, input := range inputs {
+		unique[key(input)] = input
+	}
+
+	quotes := make(map[string]Quote, len(unique))
+	errs := make(map[string]*errors.PingenError)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for k, input := range unique {
+		wg.Add(1)
+		go func(k string, input Input) {
+			defer wg.Done()
+			quote, err := q.Quote(input)
+
+			mu.Lock()
+			defer mu.Unlock()
+			quotes[k], errs[k] = quote, err
+		}(k, input)
+	}
+	wg.Wait()
+
+	estimate := Estimate{
+		Quotes: make([]Quote, 0, len(inputs)),
+		Totals: map[string]float64{},
+	}
+
+	for _, input := range inputs {
+		k := key(input)
+		if errs[k] != nil {
+			return Estimate{}, errs[k]
+		}
+
+		quote := quotes[k]
+		quote.Input = input
+		estimate.Quotes = append(estimate.Quotes, quote)
+		estimate.Totals[quote.Currency] += quote.Price
+	}
+
+	for currency, total := range estimate.Totals {
+		estimate.Totals[currency] = math.Round(total*100) / 100
+	}
+
+	return estimate, nil
+}
+
+// Purge drops every cached price, so the next quotes are looked up again.
+func (q *Quoter) Purge() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.cache = map[string]cachedQuote{}
+}
+
+// price returns the cached price for input, or joins a lookup for it that is already
+// in flight, or starts one. Failed lookups are not cached.
+func (q *Quoter) price(input Input) (string, float64, *errors.PingenError) {
+	k := key(input)
+
+	q.mu.Lock()
+	if cached, ok := q.cache[k]; ok {
+		if cached.expiresAt.IsZero() || time.Now().Before(cached.expiresAt) {
+			q.mu.Unlock()
+			return cached.currency, cached.price, nil
+		}
+		delete(q.cache, k)
+	}
+
+	if current, ok := q.inFlight[k]; ok {
+		q.mu.Unlock()
+		<-current.done
+		return current.currency, current.price, current.err
+	}
+
+	current := &lookup{done: make(chan struct{})}
+	q.inFlight[k] = current
+	q.mu.Unlock()
+
+	q.slots <- struct{}{}
+	response, err := q.letterClient.CalculatePrice(
+		input.Country,
+		input.PaperTypes,
+		input.PrintMode,
+		input.PrintSpectrum,
+		input.DeliveryProduct,
+	)
+	<-q.slots
+
+	current.currency = response.Data.Attributes.Currency
+	current.price = response.Data.Attributes.Price
+	current.err = err
+
+	q.mu.Lock()
+	delete(q.inFlight, k)
+	if err == nil {
+		cached := cachedQuote{currency: current.currency, price: current.price}
+		if q.ttl > 0 {
+			cached.expiresAt = time.Now().Add(q.ttl)
+		}
+		q.cache[k] = cached
+	}
+	q.mu.Unlock()
+
+	close(current.done)
+
+	return current.currency, current.price, current.err
+}
+
+func key(input Input) string {
+	return strings.Join([]string{
+		strings.ToUpper(input.Country),
+		strings.Join(input.PaperTypes, ","),
+		input.PrintMode,
+		input.PrintSpectrum,
+		input.DeliveryProduct,
+	}, "|")
+}
@@ -0,0 +1,209 @@
+package pricing_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go"
+	"github.com/pingencom/pingen2-sdk-go/api"
+	"github.com/pingencom/pingen2-sdk-go/letters"
+	"github.com/pingencom/pingen2-sdk-go/pricing"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAPI struct {
+	server   *httptest.Server
+	lookups  int32
+	inFlight int32
+	maxSeen  int32
+	delay    time.Duration
+}
+
+var prices = map[string]string{
+	"CH": `{"currency": "CHF", "price": 0.85}`,
+	"DE": `{"currency": "EUR", "price": 1.3}`,
+	"AT": `{"currency": "EUR", "price": 1.15}`,
+}
+
+func newMockAPI(t *testing.T) *mockAPI {
+	m := &mockAPI{}
+
+	m.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/organisations/testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1/letters/price-calculator", r.URL.Path)
+
+		atomic.AddInt32(&m.lookups, 1)
+		current := atomic.AddInt32(&m.inFlight, 1)
+		defer atomic.AddInt32(&m.inFlight, -1)
+
+		for {
+			seen := atomic.LoadInt32(&m.maxSeen)
+			if current <= seen || atomic.CompareAndSwapInt32(&m.maxSeen, seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(m.delay)
+
+		var payload struct {
+			Data struct {
+				Attributes struct {
+					Country string `json:"country"`
+				} `json:"attributes"`
+			} `json:"data"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &payload)
+
+		price, ok := prices[payload.Data.Attributes.Country]
+		if !ok {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"errors": [{"detail": "unsupported country"}]}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprintf(w, `{"data": {"id": "price", "type": "letter_price_calculator", "attributes": %s}}`, price)
+	}))
+
+	return m
+}
+
+func setupQuoter(apiBaseURL string, options pricing.Options) *pricing.Quoter {
+	config, _ := pingen2sdk.InitSDK("testSetClientId", "testSetClientSecret", "")
+	config.SetAPIBaseURL(apiBaseURL)
+	apiRequestor := api.NewAPIRequestor("dummyToken", config)
+
+	return pricing.NewQuoter(letters.NewLetters("testxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxx1", apiRequestor), options)
+}
+
+func newInput(country string) pricing.Input {
+	return pricing.Input{
+		Country:         country,
+		PaperTypes:      []string{"normal"},
+		PrintMode:       "simplex",
+		PrintSpectrum:   "grayscale",
+		DeliveryProduct: "cheap",
+	}
+}
+
+func TestQuoter_Quote(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	quoter := setupQuoter(mock.server.URL, pricing.Options{})
+
+	quote, err := quoter.Quote(newInput("CH"))
+	assert.Nil(t, err)
+	assert.Equal(t, "CHF", quote.Currency)
+	assert.Equal(t, 0.85, quote.Price)
+
+	quote, err = quoter.Quote(newInput("ch"))
+	assert.Nil(t, err)
+	assert.Equal(t, 0.85, quote.Price)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&mock.lookups))
+
+	duplex := newInput("CH")
+	duplex.PrintMode = "duplex"
+	_, err = quoter.Quote(duplex)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&mock.lookups))
+}
+
+func TestQuoter_TTLAndPurge(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	quoter := setupQuoter(mock.server.URL, pricing.Options{TTL: 20 * time.Millisecond})
+
+	_, _ = quoter.Quote(newInput("CH"))
+	_, _ = quoter.Quote(newInput("CH"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&mock.lookups))
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, _ = quoter.Quote(newInput("CH"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&mock.lookups))
+
+	quoter.Purge()
+
+	_, _ = quoter.Quote(newInput("CH"))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&mock.lookups))
+}
+
+func TestQuoter_ErrorsAreNotCached(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	quoter := setupQuoter(mock.server.URL, pricing.Options{})
+
+	_, err := quoter.Quote(newInput("XX"))
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+
+	_, err = quoter.Quote(newInput("XX"))
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&mock.lookups))
+}
+
+func TestQuoter_ConcurrentQuotesShareLookup(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.delay = 20 * time.Millisecond
+	defer mock.server.Close()
+
+	quoter := setupQuoter(mock.server.URL, pricing.Options{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			quote, err := quoter.Quote(newInput("DE"))
+			assert.Nil(t, err)
+			assert.Equal(t, 1.3, quote.Price)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&mock.lookups))
+}
+
+func TestQuoter_Estimate(t *testing.T) {
+	mock := newMockAPI(t)
+	mock.delay = 20 * time.Millisecond
+	defer mock.server.Close()
+
+	quoter := setupQuoter(mock.server.URL, pricing.Options{Concurrency: 2})
+
+	var inputs []pricing.Input
+	for i := 0; i < 100; i++ {
+		inputs = append(inputs, newInput("CH"), newInput("DE"), newInput("AT"))
+	}
+
+	estimate, err := quoter.Estimate(inputs)
+
+	assert.Nil(t, err)
+	assert.Len(t, estimate.Quotes, 300)
+	assert.Equal(t, "EUR", estimate.Quotes[1].Currency)
+	assert.Equal(t, map[string]float64{"CHF": 85, "EUR": 245}, estimate.Totals)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&mock.lookups))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&mock.maxSeen))
+}
+
+func TestQuoter_EstimateError(t *testing.T) {
+	mock := newMockAPI(t)
+	defer mock.server.Close()
+
+	quoter := setupQuoter(mock.server.URL, pricing.Options{})
+
+	_, err := quoter.Estimate([]pricing.Input{newInput("CH"), newInput("XX"), newInput("DE")})
+
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+}
@@ -0,0 +1,100 @@
+package qrbill
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/ebills"
+)
+
+// Swico S1 tags used in the billing information.
+const (
+	tagInvoiceNumber = "10"
+	tagInvoiceDate   = "11"
+	tagConditions    = "40"
+)
+
+// SwicoS1 returns the Swico S1 billing information by tag, e.g. "10" for the
+// invoice number. It is empty when the bill has none in S1 syntax.
+func (b Bill) SwicoS1() map[string]string {
+	if !strings.HasPrefix(b.BillingInformation, "//S1/") {
+		return map[string]string{}
+	}
+
+	// Slashes inside values are escaped as "\/".
+	var parts []string
+	var current strings.Builder
+	body := b.BillingInformation[len("//S1/"):]
+	for i := 0; i < len(body); i++ {
+		switch {
+		case body[i] == '\\' && i+1 < len(body) && body[i+1] == '/':
+			current.WriteByte('/')
+			i++
+		case body[i] == '/':
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteByte(body[i])
+		}
+	}
+	parts = append(parts, current.String())
+
+	info := map[string]string{}
+	for i := 0; i+1 < len(parts); i += 2 {
+		info[parts[i]] = parts[i+1]
+	}
+
+	return info
+}
+
+// Invoice maps the bill onto ebill invoice metadata for the given recipient. The invoice
+// number, date and payment term come from the Swico S1 billing information; without it the
+// reference is used as the invoice number and the dates are left for the caller to fill in.
+func (b Bill) Invoice(recipientIdentifier string) ebills.Invoice {
+	info := b.SwicoS1()
+
+	invoice := ebills.Invoice{
+		RecipientIdentifier: recipientIdentifier,
+		InvoiceNumber:       info[tagInvoiceNumber],
+		InvoiceValue:        b.Amount,
+		InvoiceCurrency:     b.Currency,
+	}
+
+	if invoice.InvoiceNumber == "" {
+		invoice.InvoiceNumber = b.Reference
+	}
+
+	if date := info[tagInvoiceDate]; len(date) >= 6 {
+		if parsed, err := time.Parse("060102", date[:6]); err == nil {
+			invoice.InvoiceDate = parsed
+		}
+	}
+
+	if days, ok := paymentTerm(info[tagConditions]); ok && !invoice.InvoiceDate.IsZero() {
+		invoice.InvoiceDueDate = invoice.InvoiceDate.AddDate(0, 0, days)
+	}
+
+	return invoice
+}
+
+// paymentTerm returns the days until the full amount is due from S1 conditions such as
+// "2:10;0:30", where the entry with a 0% discount is the net term.
+func paymentTerm(conditions string) (int, bool) {
+	for _, condition := range strings.Split(conditions, ";") {
+		discount, days, found := strings.Cut(condition, ":")
+		if !found {
+			continue
+		}
+
+		if rate, err := strconv.ParseFloat(discount, 64); err != nil || rate != 0 {
+			continue
+		}
+
+		if n, err := strconv.Atoi(days); err == nil {
+			return n, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,359 @@
+package qrbill
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pingencom/pingen2-sdk-go/errors"
+)
+
+type AddressType string
+
+const (
+	// AddressTypeStructured has the street, building number, postal code and town in separate fields.
+	AddressTypeStructured AddressType = "S"
+	// AddressTypeCombined has two free address lines; the second holds postal code and town.
+	AddressTypeCombined AddressType = "K"
+)
+
+type ReferenceType string
+
+const (
+	ReferenceQR       ReferenceType = "QRR"
+	ReferenceCreditor ReferenceType = "SCOR"
+	ReferenceNone     ReferenceType = "NON"
+)
+
+// Address is a creditor or debtor address. For AddressTypeCombined, Street and
+// BuildingNumber hold the two address lines and PostalCode and Town stay empty.
+type Address struct {
+	Type           AddressType
+	Name           string
+	Street         string
+	BuildingNumber string
+	PostalCode     string
+	Town           string
+	Country        string
+}
+
+// Bill is the payment part of a Swiss QR-bill. Amount is zero when the bill leaves it open.
+// Debtor is nil when the bill has no debtor.
+type Bill struct {
+	Version               string
+	IBAN                  string
+	Creditor              Address
+	Amount                float64
+	Currency              string
+	Debtor                *Address
+	ReferenceType         ReferenceType
+	Reference             string
+	Message               string
+	BillingInformation    string
+	AlternativeProcedures []string
+}
+
+const (
+	header  = "SPC"
+	coding  = "1"
+	trailer = "EPD"
+
+	// Lines up to and including the trailer; billing information and up to two
+	// alternative procedures may follow.
+	requiredLines = 31
+	maxLines      = 34
+)
+
+var (
+	versionPattern   = regexp.MustCompile(`^02\d\d$`)
+	amountPattern    = regexp.MustCompile(`^\d{1,9}(\.\d{1,2})?$`)
+	countryPattern   = regexp.MustCompile(`^[A-Z]{2}$`)
+	ibanPattern      = regexp.MustCompile(`^(CH|LI)\d{2}[0-9A-Z]{17}$`)
+	qrrPattern       = regexp.MustCompile(`^\d{27}$`)
+	scorPattern      = regexp.MustCompile(`^RF\d{2}[0-9A-Z]{1,21}$`)
+	qrrCheckDigits   = [10]int{0, 9, 4, 6, 8, 2, 7, 1, 3, 5}
+	payloadLineBreak = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+)
+
+// Parse reads the content of a QR-bill's Swiss QR code and validates it.
+func Parse(payload string) (Bill, *errors.PingenError) {
+	lines := strings.Split(strings.TrimRight(payloadLineBreak.Replace(payload), "\n"), "\n")
+
+	if len(lines) < requiredLines || len(lines) > maxLines {
+		return Bill{}, newPayloadError(fmt.Sprintf("expected %d to %d lines, got %d", requiredLines, maxLines, len(lines)))
+	}
+
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+
+	if lines[0] != header {
+		return Bill{}, newPayloadError(fmt.Sprintf("expected header %q, got %q", header, lines[0]))
+	}
+
+	if !versionPattern.MatchString(lines[1]) {
+		return Bill{}, newPayloadError(fmt.Sprintf("unsupported version %q", lines[1]))
+	}
+
+	if lines[2] != coding {
+		return Bill{}, newPayloadError(fmt.Sprintf("unsupported coding type %q", lines[2]))
+	}
+
+	if lines[30] != trailer {
+		return Bill{}, newPayloadError(fmt.Sprintf("expected trailer %q, got %q", trailer, lines[30]))
+	}
+
+	bill := Bill{
+		Version:       lines[1],
+		IBAN:          normalizeIBAN(lines[3]),
+		Creditor:      address(lines[4:11]),
+		Currency:      lines[19],
+		Debtor:        optionalAddress(lines[20:27]),
+		ReferenceType: ReferenceType(lines[27]),
+		Reference:     strings.ReplaceAll(lines[28], " ", ""),
+		Message:       lines[29],
+	}
+
+	if len(lines) > 31 {
+		bill.BillingInformation = lines[31]
+	}
+
+	for _, procedure := range lines[min(len(lines), 32):] {
+		if procedure != "" {
+			bill.AlternativeProcedures = append(bill.AlternativeProcedures, procedure)
+		}
+	}
+
+	if lines[18] != "" {
+		if !amountPattern.MatchString(lines[18]) {
+			return Bill{}, newValidationError(map[string]string{"amount": "must be a number with at most 2 decimal places"})
+		}
+		bill.Amount, _ = strconv.ParseFloat(lines[18], 64)
+	}
+
+	if err := bill.Validate(); err != nil {
+		return Bill{}, err
+	}
+
+	return bill, nil
+}
+
+// FindAll returns the QR-bills in text, such as text extracted from a PDF. A bill starts at
+// a line reading "SPC" and ends at its trailer, optionally followed by billing information.
+// Blocks that look like a QR-bill but are invalid are reported with their error.
+func FindAll(text string) ([]Bill, []*errors.PingenError) {
+	lines := strings.Split(payloadLineBreak.Replace(text), "\n")
+
+	var bills []Bill
+	var errs []*errors.PingenError
+
+	for i := 0; i+requiredLines <= len(lines); i++ {
+		if strings.TrimSpace(lines[i]) != header || !versionPattern.MatchString(strings.TrimSpace(lines[i+1])) {
+			continue
+		}
+
+		end := i + requiredLines
+		if end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), "//") {
+			end++
+		}
+
+		bill, err := Parse(strings.Join(lines[i:end], "\n"))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		bills = append(bills, bill)
+		i = end - 1
+	}
+
+	return bills, errs
+}
+
+// Validate checks the bill against the Swiss QR-bill rules and returns a 422 listing every
+// invalid field, or nil.
+func (b Bill) Validate() *errors.PingenError {
+	problems := map[string]string{}
+
+	iban := normalizeIBAN(b.IBAN)
+	switch {
+	case !ibanPattern.MatchString(iban):
+		problems["iban"] = "must be a Swiss or Liechtenstein IBAN"
+	case !validIBANChecksum(iban):
+		problems["iban"] = "has an invalid check digit"
+	}
+
+	switch b.ReferenceType {
+	case ReferenceQR:
+		if !qrrPattern.MatchString(b.Reference) || !validQRReference(b.Reference) {
+			problems["reference"] = "must be a 27-digit QR reference with a valid check digit"
+		}
+		if _, ok := problems["iban"]; !ok && !IsQRIBAN(iban) {
+			problems["reference_type"] = "QRR requires a QR-IBAN"
+		}
+	case ReferenceCreditor:
+		if !scorPattern.MatchString(b.Reference) || !validCreditorReference(b.Reference) {
+			problems["reference"] = "must be an ISO 11649 creditor reference"
+		}
+	case ReferenceNone:
+		if b.Reference != "" {
+			problems["reference"] = "must be empty without a reference type"
+		}
+	default:
+		problems["reference_type"] = "must be QRR, SCOR or NON"
+	}
+
+	if b.ReferenceType != ReferenceQR && IsQRIBAN(iban) {
+		problems["reference_type"] = "a QR-IBAN requires a QR reference"
+	}
+
+	if b.Currency != "CHF" && b.Currency != "EUR" {
+		problems["currency"] = "must be CHF or EUR"
+	}
+
+	if b.Amount != 0 {
+		cents := b.Amount * 100
+		switch {
+		case b.Amount < 0.01 || b.Amount > 999999999.99:
+			problems["amount"] = "must be between 0.01 and 999999999.99"
+		case math.Abs(cents-math.Round(cents)) > 1e-6:
+			problems["amount"] = "must have at most 2 decimal places"
+		}
+	}
+
+	if len([]rune(b.Message)) > 140 {
+		problems["message"] = "must be at most 140 characters"
+	}
+
+	validateAddress("creditor", b.Creditor, problems)
+	if b.Debtor != nil {
+		validateAddress("debtor", *b.Debtor, problems)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return newValidationError(problems)
+}
+
+// IsQRIBAN reports whether the IBAN is a QR-IBAN, which has an institution ID from 30000 to 31999.
+func IsQRIBAN(iban string) bool {
+	iban = normalizeIBAN(iban)
+	if len(iban) < 9 {
+		return false
+	}
+
+	iid, err := strconv.Atoi(iban[4:9])
+
+	return err == nil && iid >= 30000 && iid <= 31999
+}
+
+func validateAddress(prefix string, a Address, problems map[string]string) {
+	if a.Name == "" {
+		problems[prefix+".name"] = "is required"
+	}
+
+	switch a.Type {
+	case AddressTypeStructured:
+		if a.PostalCode == "" {
+			problems[prefix+".postal_code"] = "is required"
+		}
+		if a.Town == "" {
+			problems[prefix+".town"] = "is required"
+		}
+	case AddressTypeCombined:
+		if a.BuildingNumber == "" {
+			problems[prefix+".address_line_2"] = "is required"
+		}
+		if a.PostalCode != "" || a.Town != "" {
+			problems[prefix+".type"] = "combined addresses must not have a postal code or town"
+		}
+	default:
+		problems[prefix+".type"] = "must be S or K"
+	}
+
+	if !countryPattern.MatchString(a.Country) {
+		problems[prefix+".country"] = "must be an ISO 3166-1 alpha-2 code"
+	}
+}
+
+// validIBANChecksum applies the ISO 13616 mod-97 check.
+func validIBANChecksum(iban string) bool {
+	return mod97(iban[4:]+iban[:4]) == 1
+}
+
+// validCreditorReference applies the ISO 11649 mod-97 check.
+func validCreditorReference(reference string) bool {
+	return mod97(reference[4:]+reference[:4]) == 1
+}
+
+func mod97(value string) int64 {
+	var digits strings.Builder
+	for _, r := range value {
+		if r >= 'A' && r <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(r-'A') + 10))
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok {
+		return -1
+	}
+
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64()
+}
+
+// validQRReference applies the recursive mod-10 check digit of QR references.
+func validQRReference(reference string) bool {
+	carry := 0
+	for _, r := range reference[:len(reference)-1] {
+		carry = qrrCheckDigits[(carry+int(r-'0'))%10]
+	}
+
+	return (10-carry)%10 == int(reference[len(reference)-1]-'0')
+}
+
+func normalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+}
+
+func address(lines []string) Address {
+	return Address{
+		Type:           AddressType(lines[0]),
+		Name:           lines[1],
+		Street:         lines[2],
+		BuildingNumber: lines[3],
+		PostalCode:     lines[4],
+		Town:           lines[5],
+		Country:        lines[6],
+	}
+}
+
+func optionalAddress(lines []string) *Address {
+	for _, line := range lines {
+		if line != "" {
+			a := address(lines)
+			return &a
+		}
+	}
+
+	return nil
+}
+
+func newPayloadError(problem string) *errors.PingenError {
+	return errors.NewPingenError("Invalid QR-bill payload: "+problem, "", http.StatusUnprocessableEntity, nil)
+}
+
+func newValidationError(problems map[string]string) *errors.PingenError {
+	body, _ := json.Marshal(problems)
+
+	return errors.NewPingenError("Invalid QR-bill", string(body), http.StatusUnprocessableEntity, nil)
+}
@@ -0,0 +1,202 @@
+package qrbill_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingencom/pingen2-sdk-go/qrbill"
+	"github.com/stretchr/testify/assert"
+)
+
+var examplePayload = strings.Join([]string{
+	"SPC",
+	"0200",
+	"1",
+	"CH4431999123000889012",
+	"S",
+	"Robert Schneider AG",
+	"Rue du Lac",
+	"1268",
+	"2501",
+	"Biel",
+	"CH",
+	"", "", "", "", "", "", "",
+	"1949.75",
+	"CHF",
+	"S",
+	"Pia-Maria Rutschmann-Schnyder",
+	"Grosse Marktgasse",
+	"28",
+	"9400",
+	"Rorschach",
+	"CH",
+	"QRR",
+	"210000000003139471430009017",
+	"Order of 15 June 2020",
+	"EPD",
+	"//S1/10/10201409/11/200701/20/140.000-53/30/102673831/31/200615/32/7.7/33/7.7:1.95/40/0:30",
+	"Name AV1: UV;UltraPay005;12345",
+}, "\r\n")
+
+func withLine(payload string, index int, value string) string {
+	lines := strings.Split(payload, "\r\n")
+	lines[index] = value
+	return strings.Join(lines, "\r\n")
+}
+
+func TestParse(t *testing.T) {
+	bill, err := qrbill.Parse(examplePayload)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "0200", bill.Version)
+	assert.Equal(t, "CH4431999123000889012", bill.IBAN)
+	assert.True(t, qrbill.IsQRIBAN(bill.IBAN))
+	assert.Equal(t, qrbill.Address{
+		Type:           qrbill.AddressTypeStructured,
+		Name:           "Robert Schneider AG",
+		Street:         "Rue du Lac",
+		BuildingNumber: "1268",
+		PostalCode:     "2501",
+		Town:           "Biel",
+		Country:        "CH",
+	}, bill.Creditor)
+	assert.Equal(t, 1949.75, bill.Amount)
+	assert.Equal(t, "CHF", bill.Currency)
+	assert.Equal(t, "Rorschach", bill.Debtor.Town)
+	assert.Equal(t, qrbill.ReferenceQR, bill.ReferenceType)
+	assert.Equal(t, "210000000003139471430009017", bill.Reference)
+	assert.Equal(t, "Order of 15 June 2020", bill.Message)
+	assert.Equal(t, []string{"Name AV1: UV;UltraPay005;12345"}, bill.AlternativeProcedures)
+	assert.Equal(t, "10201409", bill.SwicoS1()["10"])
+}
+
+func TestParse_CreditorReference(t *testing.T) {
+	payload := withLine(examplePayload, 3, "CH58 0079 1123 0008 8901 2")
+	payload = withLine(payload, 27, "SCOR")
+	payload = withLine(payload, 28, "RF18 5390 0754 7034")
+	for i := 20; i <= 26; i++ {
+		payload = withLine(payload, i, "")
+	}
+	payload = withLine(payload, 18, "")
+
+	bill, err := qrbill.Parse(payload)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "CH5800791123000889012", bill.IBAN)
+	assert.False(t, qrbill.IsQRIBAN(bill.IBAN))
+	assert.Equal(t, "RF18539007547034", bill.Reference)
+	assert.Nil(t, bill.Debtor)
+	assert.Equal(t, 0.0, bill.Amount)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		message  string
+		problems map[string]interface{}
+	}{
+		{
+			name:    "too short",
+			payload: "SPC\n0200\n1",
+			message: "Invalid QR-bill payload: expected 31 to 34 lines, got 3",
+		},
+		{
+			name:    "header",
+			payload: withLine(examplePayload, 0, "BCD"),
+			message: `Invalid QR-bill payload: expected header "SPC", got "BCD"`,
+		},
+		{
+			name:    "trailer",
+			payload: withLine(examplePayload, 30, "END"),
+			message: `Invalid QR-bill payload: expected trailer "EPD", got "END"`,
+		},
+		{
+			name:     "iban check digit",
+			payload:  withLine(examplePayload, 3, "CH4531999123000889012"),
+			message:  "Invalid QR-bill",
+			problems: map[string]interface{}{"iban": "has an invalid check digit"},
+		},
+		{
+			name:     "foreign iban",
+			payload:  withLine(examplePayload, 3, "DE89370400440532013000"),
+			message:  "Invalid QR-bill",
+			problems: map[string]interface{}{"iban": "must be a Swiss or Liechtenstein IBAN"},
+		},
+		{
+			name:     "qr reference check digit",
+			payload:  withLine(examplePayload, 28, "210000000003139471430009016"),
+			message:  "Invalid QR-bill",
+			problems: map[string]interface{}{"reference": "must be a 27-digit QR reference with a valid check digit"},
+		},
+		{
+			name:     "qr iban without qr reference",
+			payload:  withLine(withLine(examplePayload, 27, "NON"), 28, ""),
+			message:  "Invalid QR-bill",
+			problems: map[string]interface{}{"reference_type": "a QR-IBAN requires a QR reference"},
+		},
+		{
+			name:     "qr reference without qr iban",
+			payload:  withLine(examplePayload, 3, "CH5800791123000889012"),
+			message:  "Invalid QR-bill",
+			problems: map[string]interface{}{"reference_type": "QRR requires a QR-IBAN"},
+		},
+		{
+			name:     "amount precision",
+			payload:  withLine(examplePayload, 18, "1949.755"),
+			message:  "Invalid QR-bill",
+			problems: map[string]interface{}{"amount": "must be a number with at most 2 decimal places"},
+		},
+		{
+			name:     "currency and creditor",
+			payload:  withLine(withLine(examplePayload, 19, "USD"), 5, ""),
+			message:  "Invalid QR-bill",
+			problems: map[string]interface{}{"currency": "must be CHF or EUR", "creditor.name": "is required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := qrbill.Parse(tt.payload)
+
+			assert.NotNil(t, err)
+			assert.Equal(t, http.StatusUnprocessableEntity, err.StatusCode)
+			assert.Equal(t, tt.message, err.Message)
+			if tt.problems != nil {
+				assert.Equal(t, tt.problems, err.JSONBody)
+			}
+		})
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	text := "Invoice 10201409\n\n" + examplePayload + "\nThank you\n" + withLine(examplePayload, 28, "210000000003139471430009016")
+
+	bills, errs := qrbill.FindAll(text)
+
+	assert.Len(t, bills, 1)
+	assert.Equal(t, "210000000003139471430009017", bills[0].Reference)
+	assert.NotEmpty(t, bills[0].BillingInformation)
+	assert.Len(t, errs, 1)
+}
+
+func TestInvoice(t *testing.T) {
+	bill, err := qrbill.Parse(examplePayload)
+	assert.Nil(t, err)
+
+	invoice := bill.Invoice("41100010014282213")
+
+	assert.Nil(t, invoice.Validate())
+	assert.Equal(t, "10201409", invoice.InvoiceNumber)
+	assert.Equal(t, time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), invoice.InvoiceDate)
+	assert.Equal(t, time.Date(2020, 7, 31, 0, 0, 0, 0, time.UTC), invoice.InvoiceDueDate)
+	assert.Equal(t, 1949.75, invoice.InvoiceValue)
+	assert.Equal(t, "CHF", invoice.InvoiceCurrency)
+
+	bill.BillingInformation = ""
+	invoice = bill.Invoice("41100010014282213")
+	assert.Equal(t, "210000000003139471430009017", invoice.InvoiceNumber)
+	assert.True(t, invoice.InvoiceDate.IsZero())
+}
@@ -32,6 +32,10 @@ type Meta struct {
 	Total       int `json:"total"`
 }
 
+// AbilityOK is the value the API reports in meta.abilities for an ability that is currently
+// granted. Any other value (e.g. "state" or "permission") names the reason it is denied.
+const AbilityOK = "ok"
+
 type BaseListResponse struct {
 	Included []struct{} `json:"included"`
 	Links    Links      `json:"links"`